@@ -0,0 +1,356 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// The debug frames annotation colors
+var (
+	annotRegion = color.RGBA{0, 128, 255, 255}
+	annotMatch  = color.RGBA{255, 0, 0, 255}
+	annotPoint  = color.RGBA{255, 0, 255, 255}
+	annotPath   = color.RGBA{255, 160, 0, 255}
+)
+
+// fillRect fill the rect clipped by the image bounds
+func fillRect(img draw.Image, r image.Rectangle, c color.Color) {
+	r = r.Intersect(img.Bounds())
+	if !r.Empty() {
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Over)
+	}
+}
+
+// DrawRect draw the rect border into the image, (0, 0) is the image
+// top-left, the border width default is 2
+//
+// Examples:
+//
+//	img, _ := robotgo.CaptureImg(0, 0, 800, 600)
+//	m, _ := robotgo.FindBitmap(tpl, img)
+//	robotgo.DrawRect(img.(draw.Image), m.Rect, color.RGBA{255, 0, 0, 255})
+func DrawRect(img draw.Image, r Rect, c color.Color, width ...int) {
+	w := 2
+	if len(width) > 0 {
+		w = width[0]
+	}
+
+	o := img.Bounds().Min
+	x0, y0 := o.X+r.X, o.Y+r.Y
+	x1, y1 := x0+r.W, y0+r.H
+
+	fillRect(img, image.Rect(x0, y0, x1, y0+w), c)
+	fillRect(img, image.Rect(x0, y1-w, x1, y1), c)
+	fillRect(img, image.Rect(x0, y0+w, x0+w, y1-w), c)
+	fillRect(img, image.Rect(x1-w, y0+w, x1, y1-w), c)
+}
+
+// DrawCross draw the crosshair of the point into the image,
+// the size is the arm length, default is 10
+func DrawCross(img draw.Image, x, y int, c color.Color, size ...int) {
+	n := 10
+	if len(size) > 0 {
+		n = size[0]
+	}
+
+	o := img.Bounds().Min
+	x, y = o.X+x, o.Y+y
+	fillRect(img, image.Rect(x-n, y-1, x+n+1, y+1), c)
+	fillRect(img, image.Rect(x-1, y-n, x+1, y+n+1), c)
+	// The hollow box makes the point visible on the same color
+	DrawRect(img, Rect{Point{X: x - o.X - 3, Y: y - o.Y - 3}, Size{W: 7, H: 7}}, c, 1)
+}
+
+// DrawPath draw the polyline of the points into the image,
+// e.g. the mouse path
+func DrawPath(img draw.Image, path []Point, c color.Color) {
+	o := img.Bounds().Min
+	for i := range path {
+		p0, p1 := path[i], path[i]
+		if i > 0 {
+			p0 = path[i-1]
+		}
+		drawLine(img, o.X+p0.X, o.Y+p0.Y, o.X+p1.X, o.Y+p1.Y, c)
+	}
+}
+
+// drawLine draw the 2 pixels line by the Bresenham algorithm
+func drawLine(img draw.Image, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		fillRect(img, image.Rect(x0, y0, x0+2, y0+2), c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// labelSize get the label box size of the text
+func labelSize(text string) (int, int) {
+	face := basicfont.Face7x13
+	return font.MeasureString(face, text).Ceil() + 4, face.Height + 2
+}
+
+// DrawLabel draw the text label into the image, (x, y) is the label
+// top-left, the label is moved into the image if it's out of the bounds,
+// the background is the color and the text is black or white
+func DrawLabel(img draw.Image, x, y int, text string, c color.Color) {
+	b := img.Bounds()
+	w, h := labelSize(text)
+	x = max(b.Min.X, min(b.Min.X+x, b.Max.X-w))
+	y = max(b.Min.Y, min(b.Min.Y+y, b.Max.Y-h))
+	fillRect(img, image.Rect(x, y, x+w, y+h), c)
+
+	fg := color.Color(color.White)
+	if r, g, bl, _ := c.RGBA(); (299*r+587*g+114*bl)/1000 > 0x8000 {
+		fg = color.Black
+	}
+
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(fg),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x+2, y+1+basicfont.Face7x13.Ascent),
+	}
+	d.DrawString(text)
+}
+
+// Annotator draw the annotations onto the capture by the screen points
+//
+// Examples:
+//
+//	a, _ := robotgo.CaptureAnnotator()
+//	a.Rect(m.Rect, color.RGBA{255, 0, 0, 255}, "button")
+//	a.Cross(100, 200, color.RGBA{255, 0, 255, 255}, "click")
+//	a.Save("debug.png")
+type Annotator struct {
+	Img *image.RGBA
+	// Origin is the image origin on the screen
+	Origin Point
+}
+
+// NewAnnotator create the annotator of the image copy,
+// the origin (x, y) is the image origin on the screen, default is (0, 0)
+//
+// robotgo.NewAnnotator(img image.Image, x, y int)
+func NewAnnotator(img image.Image, origin ...int) *Annotator {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Src)
+
+	a := &Annotator{Img: dst}
+	if len(origin) > 1 {
+		a.Origin = Point{X: origin[0], Y: origin[1]}
+	}
+	return a
+}
+
+// CaptureAnnotator capture the screen and create the annotator,
+// the args is the same as the CaptureImg()
+//
+// robotgo.CaptureAnnotator(x, y, w, h int)
+func CaptureAnnotator(args ...int) (*Annotator, error) {
+	var region interface{}
+	if len(args) > 3 {
+		region = Rect{Point{X: args[0], Y: args[1]}, Size{W: args[2], H: args[3]}}
+	}
+
+	src, o, err := regionRGBA(region)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnnotator(src, o.X, o.Y), nil
+}
+
+// Rect draw the screen rect and the label above it
+func (a *Annotator) Rect(r Rect, c color.Color, label ...string) {
+	r.X -= a.Origin.X
+	r.Y -= a.Origin.Y
+	DrawRect(a.Img, r, c)
+
+	if len(label) > 0 && label[0] != "" {
+		_, h := labelSize(label[0])
+		DrawLabel(a.Img, r.X, r.Y-h, label[0], c)
+	}
+}
+
+// Cross draw the crosshair of the screen point and the label beside it
+func (a *Annotator) Cross(x, y int, c color.Color, label ...string) {
+	x -= a.Origin.X
+	y -= a.Origin.Y
+	DrawCross(a.Img, x, y, c)
+
+	if len(label) > 0 && label[0] != "" {
+		DrawLabel(a.Img, x+12, y+4, label[0], c)
+	}
+}
+
+// Path draw the polyline of the screen points
+func (a *Annotator) Path(path []Point, c color.Color) {
+	arr := make([]Point, len(path))
+	for i, p := range path {
+		arr[i] = Point{X: p.X - a.Origin.X, Y: p.Y - a.Origin.Y}
+	}
+	DrawPath(a.Img, arr, c)
+}
+
+// Label draw the text label at the screen point
+func (a *Annotator) Label(x, y int, text string, c color.Color) {
+	DrawLabel(a.Img, x-a.Origin.X, y-a.Origin.Y, text, c)
+}
+
+// Save save the annotated image to the png file
+func (a *Annotator) Save(path string) error {
+	return SavePng(a.Img, path)
+}
+
+var debug struct {
+	sync.Mutex
+	dir string
+	n   int
+}
+
+// SetDebug turn on the debug mode, the annotated screen frames are
+// saved to the dir after the actions (Move, MoveSmooth, Click,
+// FindBitmap, FindAllBitmaps and LocateText), the frames are named
+// by the sequence and the action, e.g. "0001-Move.png",
+// the "" turn off it
+//
+// Examples:
+//
+//	robotgo.SetDebug("frames")
+//	defer robotgo.SetDebug("")
+func SetDebug(dir string) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	debug.Lock()
+	debug.dir, debug.n = dir, 0
+	debug.Unlock()
+	return nil
+}
+
+// IsDebug return true if the debug mode is on
+func IsDebug() bool {
+	debug.Lock()
+	defer debug.Unlock()
+	return debug.dir != ""
+}
+
+// DebugFrame save the annotated screen frame to the debug dir,
+// the fn draws the annotations, do nothing if the debug mode is off
+//
+// Examples:
+//
+//	robotgo.DebugFrame("login", func(a *robotgo.Annotator) {
+//		a.Rect(form, color.RGBA{0, 255, 0, 255}, "login form")
+//	})
+func DebugFrame(name string, fn func(a *Annotator)) error {
+	return saveDebug(name, nil, Point{}, fn)
+}
+
+// saveDebug save the debug frame of the src, nil is the screen capture
+func saveDebug(name string, src *image.RGBA, o Point, fn func(a *Annotator)) error {
+	debug.Lock()
+	dir := debug.dir
+	if dir == "" {
+		debug.Unlock()
+		return nil
+	}
+	debug.n++
+	n := debug.n
+	debug.Unlock()
+
+	if src == nil {
+		var err error
+		src, o, err = regionRGBA(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	a := NewAnnotator(src, o.X, o.Y)
+	fn(a)
+	return a.Save(filepath.Join(dir, fmt.Sprintf("%04d-%s.png", n, name)))
+}
+
+// debugFrame save the debug frame of the action, log the errors
+func debugFrame(name string, src *image.RGBA, o Point, fn func(a *Annotator)) {
+	if err := saveDebug(name, src, o, fn); err != nil {
+		log.Println("debug frame errors is: ", err)
+	}
+}
+
+// debugFind save the debug frame of the search, the live screen region
+// is drawn on the screen capture, the fn draws the matches, nil is not found
+func debugFind(name string, region interface{}, src *image.RGBA, o Point, fn func(a *Annotator)) {
+	var r *Rect
+	switch v := region.(type) {
+	case Rect:
+		r = &v
+	case *Rect:
+		r = v
+	}
+	if r != nil {
+		src = nil
+	}
+
+	debugFrame(name, src, o, func(a *Annotator) {
+		label := name
+		if fn == nil {
+			label += ": not found"
+		}
+
+		if r != nil {
+			a.Rect(*r, annotRegion, label)
+		} else {
+			a.Label(a.Origin.X, a.Origin.Y, label, annotRegion)
+		}
+		if fn != nil {
+			fn(a)
+		}
+	})
+}
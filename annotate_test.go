@@ -0,0 +1,119 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestDrawAnnotate(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	img := image.NewRGBA(image.Rect(0, 0, 100, 80))
+
+	DrawRect(img, Rect{Point{X: 10, Y: 10}, Size{W: 20, H: 10}}, red)
+	tt.Equal(t, red, img.RGBAAt(10, 10))
+	tt.Equal(t, red, img.RGBAAt(29, 19))
+	tt.Equal(t, red, img.RGBAAt(11, 15))
+	tt.Equal(t, color.RGBA{}, img.RGBAAt(15, 15))
+	tt.Equal(t, color.RGBA{}, img.RGBAAt(30, 15))
+
+	DrawCross(img, 60, 40, red)
+	tt.Equal(t, red, img.RGBAAt(50, 40))
+	tt.Equal(t, red, img.RGBAAt(60, 50))
+	tt.Equal(t, color.RGBA{}, img.RGBAAt(49, 40))
+
+	DrawPath(img, []Point{{X: 0, Y: 70}, {X: 40, Y: 70}, {X: 40, Y: 60}}, red)
+	tt.Equal(t, red, img.RGBAAt(20, 70))
+	tt.Equal(t, red, img.RGBAAt(40, 65))
+
+	// The label is moved into the image
+	sub := img.SubImage(image.Rect(50, 0, 100, 30)).(*image.RGBA)
+	DrawLabel(sub, 40, -5, "ok", red)
+	w, h := labelSize("ok")
+	tt.Equal(t, 18, w)
+	tt.Equal(t, 15, h)
+	tt.Equal(t, red, img.RGBAAt(100-w, 0))
+	tt.Equal(t, color.RGBA{}, img.RGBAAt(100-w-1, 0))
+
+	var text int
+	for y := 0; y < h; y++ {
+		for x := 100 - w; x < 100; x++ {
+			if img.RGBAAt(x, y) == (color.RGBA{255, 255, 255, 255}) {
+				text++
+			}
+		}
+	}
+	tt.True(t, text > 10)
+
+	a := NewAnnotator(img.SubImage(image.Rect(0, 0, 50, 50)), 100, 200)
+	a.Rect(Rect{Point{X: 110, Y: 210}, Size{W: 5, H: 5}}, red)
+	tt.Equal(t, image.Rect(0, 0, 50, 50), a.Img.Rect)
+	tt.Equal(t, red, a.Img.RGBAAt(10, 10))
+	tt.Equal(t, color.RGBA{}, img.RGBAAt(12, 21))
+}
+
+func TestSmoothMove(t *testing.T) {
+	var path []Point
+	ok := smoothMove(10, 10, 300, -50, func(x, y int) {
+		path = append(path, Point{X: x, Y: y})
+	})
+	tt.True(t, ok)
+
+	end := path[len(path)-1]
+	tt.True(t, abs(end.X-300) <= 1 && abs(end.Y+50) <= 1)
+
+	p := Point{X: 10, Y: 10}
+	for _, p1 := range path {
+		tt.True(t, abs(p1.X-p.X) <= 1 && abs(p1.Y-p.Y) <= 1)
+		p = p1
+	}
+
+	n := 0
+	tt.True(t, smoothMove(5, 5, 5, 5, func(x, y int) { n++ }))
+	tt.Equal(t, 0, n)
+}
+
+func TestDebugFrame(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frames")
+	tt.Nil(t, SetDebug(dir))
+	t.Cleanup(func() { SetDebug("") })
+	tt.True(t, IsDebug())
+
+	src := testScreen(400, 300)
+	testButton(src, 123, 77)
+	tpl := src.SubImage(image.Rect(123, 77, 163, 97))
+
+	_, err := FindBitmap(tpl, src)
+	tt.Nil(t, err)
+	_, err = FindBitmap(tpl, src.SubImage(image.Rect(0, 0, 30, 30)))
+	tt.Equal(t, ErrNotFound, err)
+	// The search image is not changed
+	tt.Equal(t, testScreen(400, 300).RGBAAt(0, 0), src.RGBAAt(0, 0))
+
+	img, err := Read(filepath.Join(dir, "0001-FindBitmap.png"))
+	tt.Nil(t, err)
+	tt.Equal(t, annotMatch, color.RGBAModel.Convert(img.At(123, 80)))
+	_, err = os.Stat(filepath.Join(dir, "0002-FindBitmap.png"))
+	tt.Nil(t, err)
+
+	tt.Nil(t, SetDebug(""))
+	tt.False(t, IsDebug())
+	tt.Nil(t, DebugFrame("off", func(a *Annotator) {
+		t.Error("the debug mode is off")
+	}))
+}
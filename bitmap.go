@@ -0,0 +1,247 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+var errCapture = errors.New("Capture image not found.")
+
+// GoBitmap is the Go-managed bitmap, the 32 bits BGRA pixels
+// are in the Go memory (same as the CBitmap layout), no need to free.
+//
+// It's the image.Image and draw.Image, the same as the image.RGBA.
+type GoBitmap struct {
+	// Pix is the BGRA pixels, the pixel at (x, y) starts at
+	// Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)*4]
+	Pix    []uint8
+	Stride int
+	Rect   image.Rectangle
+}
+
+// NewGoBitmap create the GoBitmap with the bounds
+func NewGoBitmap(r image.Rectangle) *GoBitmap {
+	return &GoBitmap{
+		Pix:    make([]uint8, 4*r.Dx()*r.Dy()),
+		Stride: 4 * r.Dx(),
+		Rect:   r,
+	}
+}
+
+// ToGoBitmap copy the Bitmap pixels to the GoBitmap,
+// the Bitmap can be freed after the copy
+func ToGoBitmap(bit Bitmap) *GoBitmap {
+	b := NewGoBitmap(image.Rect(0, 0, bit.Width, bit.Height))
+	if bit.ImgBuf == nil || bit.BytesPerPixel < 3 {
+		return b
+	}
+
+	bpp := int(bit.BytesPerPixel)
+	src := unsafe.Slice(bit.ImgBuf, bit.Bytewidth*bit.Height)
+	for y := 0; y < bit.Height; y++ {
+		s, d := src[y*bit.Bytewidth:], b.Pix[y*b.Stride:]
+		if bpp == 4 {
+			copy(d[:b.Stride], s)
+			continue
+		}
+
+		for x := 0; x < bit.Width; x++ {
+			d[x*4], d[x*4+1], d[x*4+2], d[x*4+3] = s[x*3], s[x*3+1], s[x*3+2], 0xff
+		}
+	}
+	return b
+}
+
+// CBitmapToGo copy the CBitmap pixels to the GoBitmap,
+// the CBitmap is still owned by the caller
+func CBitmapToGo(bit CBitmap) *GoBitmap {
+	if bit == nil {
+		return nil
+	}
+	return ToGoBitmap(ToBitmap(bit))
+}
+
+// ImgToGoBitmap convert the image.Image to GoBitmap
+func ImgToGoBitmap(img image.Image) *GoBitmap {
+	r := img.Bounds()
+	b := NewGoBitmap(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(b, b.Rect, img, r.Min, draw.Src)
+	return b
+}
+
+// CaptureGoBitmap capture the screen and return the GoBitmap,
+// the C bitmap is freed
+//
+// robotgo.CaptureGoBitmap(x, y, w, h int)
+func CaptureGoBitmap(args ...int) (*GoBitmap, error) {
+	bit := CaptureScreen(args...)
+	if bit == nil {
+		return nil, errCapture
+	}
+	defer FreeBitmap(bit)
+
+	return CBitmapToGo(bit), nil
+}
+
+// ColorModel return the image color model
+func (b *GoBitmap) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds return the image bounds
+func (b *GoBitmap) Bounds() image.Rectangle {
+	return b.Rect
+}
+
+// PixOffset return the index of the first element of Pix
+// that corresponds to the pixel at (x, y)
+func (b *GoBitmap) PixOffset(x, y int) int {
+	return (y-b.Rect.Min.Y)*b.Stride + (x-b.Rect.Min.X)*4
+}
+
+// At return the color of the pixel at (x, y)
+func (b *GoBitmap) At(x, y int) color.Color {
+	return b.RGBAAt(x, y)
+}
+
+// RGBAAt return the color.RGBA of the pixel at (x, y)
+func (b *GoBitmap) RGBAAt(x, y int) color.RGBA {
+	if !(image.Point{X: x, Y: y}.In(b.Rect)) {
+		return color.RGBA{}
+	}
+
+	p := b.Pix[b.PixOffset(x, y):]
+	return color.RGBA{R: p[2], G: p[1], B: p[0], A: p[3]}
+}
+
+// Set set the color of the pixel at (x, y)
+func (b *GoBitmap) Set(x, y int, c color.Color) {
+	if !(image.Point{X: x, Y: y}.In(b.Rect)) {
+		return
+	}
+
+	c1 := color.RGBAModel.Convert(c).(color.RGBA)
+	p := b.Pix[b.PixOffset(x, y):]
+	p[0], p[1], p[2], p[3] = c1.B, c1.G, c1.R, c1.A
+}
+
+// Hex return the CHex color of the pixel at (x, y)
+func (b *GoBitmap) Hex(x, y int) CHex {
+	c := b.RGBAAt(x, y)
+	return CHex(uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B))
+}
+
+// SubImage return the image of the rect, it shares the pixels
+func (b *GoBitmap) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(b.Rect)
+	if r.Empty() {
+		return &GoBitmap{}
+	}
+
+	i := b.PixOffset(r.Min.X, r.Min.Y)
+	return &GoBitmap{Pix: b.Pix[i:], Stride: b.Stride, Rect: r}
+}
+
+// Crop copy the rect pixels to the new GoBitmap, the bounds start at (0, 0)
+func (b *GoBitmap) Crop(r image.Rectangle) *GoBitmap {
+	r = r.Intersect(b.Rect)
+	dst := NewGoBitmap(image.Rect(0, 0, r.Dx(), r.Dy()))
+	for y := 0; y < r.Dy(); y++ {
+		i := b.PixOffset(r.Min.X, r.Min.Y+y)
+		copy(dst.Pix[y*dst.Stride:(y+1)*dst.Stride], b.Pix[i:])
+	}
+	return dst
+}
+
+// Clone copy the GoBitmap
+func (b *GoBitmap) Clone() *GoBitmap {
+	dst := b.Crop(b.Rect)
+	dst.Rect = b.Rect
+	return dst
+}
+
+// Bitmap return the Bitmap of the pixels, it shares the Go memory,
+// don't pass it to the FreeBitmap()
+func (b *GoBitmap) Bitmap() Bitmap {
+	c := b
+	if b.Stride != 4*b.Rect.Dx() || len(b.Pix) == 0 {
+		c = b.Crop(b.Rect)
+	}
+	if len(c.Pix) == 0 {
+		return Bitmap{}
+	}
+
+	return Bitmap{
+		ImgBuf:        &c.Pix[0],
+		Width:         c.Rect.Dx(),
+		Height:        c.Rect.Dy(),
+		Bytewidth:     c.Stride,
+		BitsPixel:     32,
+		BytesPerPixel: 4,
+	}
+}
+
+// CBitmap copy the pixels to the new C bitmap, it's freed by the
+// CBitmapRef finalizer or the Free()
+func (b *GoBitmap) CBitmap() *CBitmapRef {
+	return NewCBitmapRef(ToCBitmap(b.Bitmap()))
+}
+
+// CBitmapRef is the CBitmap owner, the CBitmap is freed by the Free()
+// or the runtime finalizer when the CBitmapRef is unreachable
+type CBitmapRef struct {
+	once sync.Once
+	bit  CBitmap
+}
+
+// NewCBitmapRef take the ownership of the CBitmap,
+// don't call the FreeBitmap() with the CBitmap after it
+//
+// Examples:
+//
+//	ref := robotgo.NewCBitmapRef(robotgo.CaptureScreen())
+//	defer ref.Free()
+func NewCBitmapRef(bit CBitmap) *CBitmapRef {
+	r := &CBitmapRef{bit: bit}
+	runtime.SetFinalizer(r, (*CBitmapRef).Free)
+	return r
+}
+
+// CBitmap return the CBitmap, it's valid until the Free(),
+// keep the CBitmapRef alive when use it
+func (r *CBitmapRef) CBitmap() CBitmap {
+	return r.bit
+}
+
+// GoBitmap copy the pixels to the GoBitmap
+func (r *CBitmapRef) GoBitmap() *GoBitmap {
+	defer runtime.KeepAlive(r)
+	return CBitmapToGo(r.bit)
+}
+
+// Free free the CBitmap, it's safe to call it more than once
+func (r *CBitmapRef) Free() {
+	r.once.Do(func() {
+		if r.bit != nil {
+			FreeBitmap(r.bit)
+		}
+		r.bit = nil
+		runtime.SetFinalizer(r, nil)
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestGoBitmap(t *testing.T) {
+	var _ draw.Image = &GoBitmap{}
+
+	b := NewGoBitmap(image.Rect(0, 0, 6, 4))
+	b.Set(3, 2, color.RGBA{0x10, 0x20, 0x30, 0xff})
+	tt.Equal(t, color.RGBA{0x10, 0x20, 0x30, 0xff}, b.At(3, 2))
+	tt.Equal(t, CHex(0x102030), b.Hex(3, 2))
+	tt.Equal(t, []uint8{0x30, 0x20, 0x10, 0xff}, b.Pix[b.PixOffset(3, 2):][:4])
+	tt.Equal(t, color.RGBA{}, b.RGBAAt(6, 0))
+
+	sub := b.SubImage(image.Rect(2, 1, 5, 4)).(*GoBitmap)
+	tt.Equal(t, image.Rect(2, 1, 5, 4), sub.Bounds())
+	tt.Equal(t, b.At(3, 2), sub.At(3, 2))
+	// The sub image shares the pixels
+	sub.Set(4, 3, color.White)
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, b.RGBAAt(4, 3))
+
+	crop := b.Crop(image.Rect(2, 1, 5, 4))
+	tt.Equal(t, image.Rect(0, 0, 3, 3), crop.Bounds())
+	tt.Equal(t, b.At(3, 2), crop.At(1, 1))
+	crop.Set(1, 1, color.Black)
+	tt.Equal(t, uint8(0x10), b.RGBAAt(3, 2).R)
+
+	c := sub.Clone()
+	tt.Equal(t, sub.Bounds(), c.Bounds())
+	tt.Equal(t, 12, c.Stride)
+	tt.Equal(t, sub.At(4, 3), c.At(4, 3))
+
+	// The sub image Bitmap is copied to the packed pixels
+	bit := sub.Bitmap()
+	tt.Equal(t, 3, bit.Width)
+	tt.Equal(t, 12, bit.Bytewidth)
+	tt.Equal(t, color.RGBA{0x10, 0x20, 0x30, 0xff}, ToRGBAGo(bit).RGBAAt(1, 1))
+	tt.Equal(t, b.At(3, 2), ToGoBitmap(bit).At(1, 1))
+}
+
+func TestGoBitmapConv(t *testing.T) {
+	for _, bpp := range []int{3, 4} {
+		bit, buf := testBitmap(5, 3, bpp)
+		p := buf[2*bit.Bytewidth+4*bpp:]
+		p[0], p[1], p[2] = 0x30, 0x20, 0x10
+
+		b := ToGoBitmap(bit)
+		tt.Equal(t, image.Rect(0, 0, 5, 3), b.Bounds())
+		tt.Equal(t, color.RGBA{0x10, 0x20, 0x30, b.RGBAAt(4, 2).A}, b.RGBAAt(4, 2))
+		if bpp == 3 {
+			tt.Equal(t, uint8(0xff), b.RGBAAt(4, 2).A)
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(10, 10, 14, 13))
+	img.Set(12, 11, color.RGBA{1, 2, 3, 0xff})
+	b := ImgToGoBitmap(img)
+	tt.Equal(t, image.Rect(0, 0, 4, 3), b.Bounds())
+	tt.Equal(t, color.RGBA{1, 2, 3, 0xff}, b.At(2, 1))
+
+	ref := b.CBitmap()
+	// The C bitmap is the copy
+	b.Set(2, 1, color.Black)
+	tt.Equal(t, color.RGBA{1, 2, 3, 0xff}, ref.GoBitmap().At(2, 1))
+	tt.Equal(t, color.RGBA{1, 2, 3, 0xff}, ToRGBA(ref.CBitmap()).RGBAAt(2, 1))
+
+	ref.Free()
+	ref.Free()
+	tt.Nil(t, ref.CBitmap())
+	tt.Nil(t, ref.GoBitmap())
+}
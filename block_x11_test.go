@@ -0,0 +1,65 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/xproto"
+	"github.com/vcaesar/tt"
+)
+
+func TestXHotkey(t *testing.T) {
+	tt.Equal(t, "control-mod1-Escape", xHotkey([]string{"esc", "ctrl", "alt"}))
+	tt.Equal(t, "shift-F12", xHotkey([]string{"F12", "shift"}))
+	tt.Equal(t, "", xHotkey(nil))
+}
+
+func TestXvfbBlockTimeout(t *testing.T) {
+	disp := startXvfb(t, 24)
+	t.Setenv("DISPLAY", disp)
+
+	c, err := xgb.NewConnDisplay(disp)
+	tt.Nil(t, err)
+	defer c.Close()
+
+	// grab the pointer from the other client, it fails if the block holds it
+	root := xproto.Setup(c).DefaultScreen(c).Root
+	grab := func() byte {
+		r, err := xproto.GrabPointer(c, false, root, 0,
+			xproto.GrabModeAsync, xproto.GrabModeAsync,
+			xproto.WindowNone, xproto.CursorNone, xproto.TimeCurrentTime).Reply()
+		tt.Nil(t, err)
+		return r.Status
+	}
+
+	tt.Nil(t, BlockInput(true, 100))
+	defer BlockInput(false)
+	tt.True(t, IsBlocked())
+	tt.Equal(t, byte(xproto.GrabStatusAlreadyGrabbed), grab())
+
+	// the grab is kept between the injected events
+	passInput(func() {})
+	tt.True(t, IsBlocked())
+	tt.Equal(t, byte(xproto.GrabStatusAlreadyGrabbed), grab())
+
+	for i := 0; i < 20 && IsBlocked(); i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	tt.False(t, IsBlocked())
+	tt.Equal(t, byte(xproto.GrabStatusSuccess), grab())
+}
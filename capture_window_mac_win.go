@@ -0,0 +1,30 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build darwin || windows
+// +build darwin windows
+
+package robotgo
+
+import (
+	"errors"
+	"image"
+)
+
+// CaptureWindow capture the window contents without the decorations (Linux)
+func CaptureWindow(pid int, args ...int) (image.Image, error) {
+	return nil, errors.New("CaptureWindow is only supported on Linux")
+}
+
+// CaptureWindowFrame capture the window with the window manager decorations (Linux)
+func CaptureWindowFrame(pid int, args ...int) (image.Image, error) {
+	return nil, errors.New("CaptureWindowFrame is only supported on Linux")
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"errors"
+	"image"
+	"strconv"
+	"sync"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/composite"
+	"github.com/robotn/xgb/xproto"
+)
+
+var (
+	compositeOnce sync.Once
+	compositeErr  error
+)
+
+// compositeConn get the shared xgb connection with the Composite extension ready
+func compositeConn() (*xgb.Conn, error) {
+	c, err := getXConn()
+	if err != nil {
+		return nil, err
+	}
+
+	compositeOnce.Do(func() {
+		if compositeErr = composite.Init(c); compositeErr != nil {
+			return
+		}
+		// The NameWindowPixmap needs the version 0.2
+		_, compositeErr = composite.QueryVersion(c, 0, 2).Reply()
+	})
+	return c, compositeErr
+}
+
+// isCompositing check the compositing manager is running
+func isCompositing(c *xgb.Conn) bool {
+	atom, err := xAtom(c, "_NET_WM_CM_S"+strconv.Itoa(xScreenNum(c)))
+	if err != nil {
+		return false
+	}
+
+	r, err := xproto.GetSelectionOwner(c, atom).Reply()
+	return err == nil && r.Owner != 0
+}
+
+// xTopLevel get the top level window of the window,
+// it's the window manager frame of the reparenting window manager
+func xTopLevel(c *xgb.Conn, win xproto.Window) (xproto.Window, error) {
+	for {
+		r, err := xproto.QueryTree(c, win).Reply()
+		if err != nil {
+			return 0, err
+		}
+		if r.Parent == r.Root || r.Parent == 0 {
+			return win, nil
+		}
+		win = r.Parent
+	}
+}
+
+// CaptureWindow capture the window contents without the decorations (Linux)
+//
+// With the compositing manager, the window is captured from the composite
+// named pixmap, also the occluded and the off-screen part. Otherwise the
+// on-screen part of the window is captured, the occluded part is what
+// covers it or undefined, the off-screen part is transparent.
+//
+// robotgo.CaptureWindow(pid int, isPid int)
+//
+// Examples:
+//
+//	img, err := robotgo.CaptureWindow(pid)
+//	img, err = robotgo.CaptureWindow(int(xid), 1)
+func CaptureWindow(pid int, args ...int) (image.Image, error) {
+	return captureWindow(pid, false, args...)
+}
+
+// CaptureWindowFrame capture the window with the window manager decorations,
+// the same as CaptureWindow() (Linux)
+func CaptureWindowFrame(pid int, args ...int) (image.Image, error) {
+	return captureWindow(pid, true, args...)
+}
+
+func captureWindow(pid int, frame bool, args ...int) (image.Image, error) {
+	c, err := getXConn()
+	if err != nil {
+		return nil, err
+	}
+
+	win := xproto.Window(pid)
+	if len(args) == 0 && !NotPid {
+		if win, err = GetXid(xu, pid); err != nil {
+			return nil, err
+		}
+	}
+
+	top, err := xTopLevel(c, win)
+	if err != nil {
+		return nil, err
+	}
+	if frame {
+		win = top
+	}
+
+	geo, err := xproto.GetGeometry(c, xproto.Drawable(win)).Reply()
+	if err != nil {
+		return nil, err
+	}
+	w, h := int(geo.Width), int(geo.Height)
+
+	if cc, err := compositeConn(); err == nil && isCompositing(cc) {
+		if img, err := pixmapCapture(c, win, top, w, h); err == nil {
+			return img, nil
+		}
+	}
+	return windowCapture(c, win, w, h)
+}
+
+// pixmapCapture capture the window from the top level window named pixmap,
+// the redirected window contents are kept when it's occluded or off-screen
+func pixmapCapture(c *xgb.Conn, win, top xproto.Window, w, h int) (*image.RGBA, error) {
+	topGeo, err := xproto.GetGeometry(c, xproto.Drawable(top)).Reply()
+	if err != nil {
+		return nil, err
+	}
+	tr, err := xproto.TranslateCoordinates(c, win, top, 0, 0).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	pix, err := xproto.NewPixmapId(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := composite.NameWindowPixmapChecked(c, top, pix).Check(); err != nil {
+		return nil, err
+	}
+	defer xproto.FreePixmap(c, pix)
+
+	// The pixmap includes the top level window border
+	bw := int16(topGeo.BorderWidth)
+	r, err := xproto.GetImage(c, xproto.ImageFormatZPixmap, xproto.Drawable(pix),
+		tr.DstX+bw, tr.DstY+bw, uint16(w), uint16(h), 0xffffffff).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	// The pixmap image has no visual, use the window visual
+	attr, err := xproto.GetWindowAttributes(c, top).Reply()
+	if err != nil {
+		return nil, err
+	}
+	f, err := xImageFormat(c, r.Depth, attr.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	if err := f.draw(img, image.Rect(0, 0, w, h), r.Data); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// windowCapture capture the on-screen part of the window,
+// the X server returns the BadMatch for the off-screen rect
+func windowCapture(c *xgb.Conn, win xproto.Window, w, h int) (*image.RGBA, error) {
+	screen := xproto.Setup(c).DefaultScreen(c)
+	tr, err := xproto.TranslateCoordinates(c, win, screen.Root, 0, 0).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	rx, ry := int(tr.DstX), int(tr.DstY)
+	vis := image.Rect(0, 0, w, h).Intersect(image.Rect(-rx, -ry,
+		int(screen.WidthInPixels)-rx, int(screen.HeightInPixels)-ry))
+	if vis.Empty() {
+		return nil, errors.New("the window is off the screen")
+	}
+
+	r, err := xproto.GetImage(c, xproto.ImageFormatZPixmap, xproto.Drawable(win),
+		int16(vis.Min.X), int16(vis.Min.Y), uint16(vis.Dx()), uint16(vis.Dy()),
+		0xffffffff).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := xImageFormat(c, r.Depth, r.Visual)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	if err := f.draw(img, vis, r.Data); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// xPixFormat is the X ZPixmap image pixel format
+type xPixFormat struct {
+	bpp, pad int
+	msb      bool
+	// The visual color masks
+	r, g, b uint32
+}
+
+// xImageFormat get the pixel format of the depth and the visual
+func xImageFormat(c *xgb.Conn, depth byte, visual xproto.Visualid) (xPixFormat, error) {
+	setup := xproto.Setup(c)
+	f := xPixFormat{msb: setup.ImageByteOrder == xproto.ImageOrderMSBFirst}
+	for _, pf := range setup.PixmapFormats {
+		if pf.Depth == depth {
+			f.bpp, f.pad = int(pf.BitsPerPixel), int(pf.ScanlinePad)
+		}
+	}
+
+	for _, screen := range setup.Roots {
+		for _, d := range screen.AllowedDepths {
+			for _, v := range d.Visuals {
+				if v.VisualId == visual {
+					f.r, f.g, f.b = v.RedMask, v.GreenMask, v.BlueMask
+				}
+			}
+		}
+	}
+
+	if f.bpp == 0 || f.bpp%8 != 0 || f.r == 0 || f.g == 0 || f.b == 0 {
+		return f, errImgType
+	}
+	return f, nil
+}
+
+// maskShift get the shift and the bits number of the color mask
+func maskShift(mask uint32) (int, int) {
+	shift, bits := 0, 0
+	for mask != 0 && mask&1 == 0 {
+		mask >>= 1
+		shift++
+	}
+	for mask&1 == 1 {
+		mask >>= 1
+		bits++
+	}
+	return shift, bits
+}
+
+// maskChannel scale the color channel of the pixel to 8 bits
+func maskChannel(pixel uint32, shift, bits int) uint8 {
+	if bits == 0 {
+		return 0
+	}
+
+	full := uint32(1)<<bits - 1
+	v := pixel >> shift & full
+	if bits >= 8 {
+		return uint8(v >> (bits - 8))
+	}
+	return uint8((v*255 + full/2) / full)
+}
+
+// draw draw the image data into the rect of the dst by the color masks
+func (f xPixFormat) draw(dst *image.RGBA, r image.Rectangle, data []byte) error {
+	bpp := f.bpp / 8
+	stride := (r.Dx()*f.bpp + f.pad - 1) / f.pad * f.pad / 8
+	if len(data) < stride*r.Dy() {
+		return errImgType
+	}
+
+	rs, rb := maskShift(f.r)
+	gs, gb := maskShift(f.g)
+	bs, bb := maskShift(f.b)
+	for y := 0; y < r.Dy(); y++ {
+		src := data[y*stride:]
+		d := dst.Pix[(r.Min.Y+y)*dst.Stride+r.Min.X*4:]
+		for x := 0; x < r.Dx(); x++ {
+			var pixel uint32
+			for i := 0; i < bpp; i++ {
+				if f.msb {
+					pixel = pixel<<8 | uint32(src[x*bpp+i])
+				} else {
+					pixel |= uint32(src[x*bpp+i]) << (8 * i)
+				}
+			}
+
+			d[x*4] = maskChannel(pixel, rs, rb)
+			d[x*4+1] = maskChannel(pixel, gs, gb)
+			d[x*4+2] = maskChannel(pixel, bs, bb)
+			d[x*4+3] = 0xff
+		}
+	}
+	return nil
+}
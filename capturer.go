@@ -0,0 +1,147 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"unsafe"
+)
+
+var errCapturerClosed = errors.New("the capturer is closed")
+
+// Capturer is the reusable screen capturer for the polling loop,
+// it keeps one X connection and captures into the reusable MIT-SHM
+// shared memory when it's available (Linux), falls back to the XGetImage,
+// the other platforms use the CaptureScreen().
+//
+// The captures share the capturer buffer (zero-copy),
+// it's valid until the next capture or Close(), copy it to keep.
+//
+// Examples:
+//
+//	c, err := robotgo.NewCapturer()
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	for {
+//		img, err := c.CaptureRGBA(10, 10, 300, 300)
+//		...
+//	}
+type Capturer struct {
+	mu sync.Mutex
+	h  *capHandle
+
+	cursor bool
+}
+
+// NewCapturer create the reusable screen capturer, it draws the mouse
+// cursor if the CaptureCursor is set, use `defer c.Close()` to close it
+func NewCapturer() (*Capturer, error) {
+	h, err := openCapturer()
+	if err != nil {
+		return nil, err
+	}
+	return &Capturer{h: h, cursor: CaptureCursor}, nil
+}
+
+// SetCursor set the capturer draw the mouse cursor into the captures or not
+func (c *Capturer) SetCursor(b bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cursor = b
+}
+
+// IsShm return true if the capturer uses the MIT-SHM (Linux)
+func (c *Capturer) IsShm() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.h != nil && c.h.isShm()
+}
+
+// Capture capture the screen rect into the capturer buffer,
+// return the bitmap of the buffer, default is the whole screen
+//
+// robotgo.Capture(x, y, w, h int)
+func (c *Capturer) Capture(args ...int) (Bitmap, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.capture(args...)
+}
+
+func (c *Capturer) capture(args ...int) (Bitmap, error) {
+	if c.h == nil {
+		return Bitmap{}, errCapturerClosed
+	}
+
+	bit, x, y, err := c.h.grab(args...)
+	if err != nil {
+		return Bitmap{}, err
+	}
+
+	if c.cursor {
+		drawCursorBitmap(bit, x, y)
+	}
+	return bit, nil
+}
+
+// CaptureRGBA capture the screen rect, convert the capturer buffer
+// to RGBA in place and return the *image.RGBA of the buffer
+//
+// robotgo.CaptureRGBA(x, y, w, h int)
+func (c *Capturer) CaptureRGBA(args ...int) (*image.RGBA, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bit, err := c.capture(args...)
+	if err != nil {
+		return nil, err
+	}
+	if bit.BytesPerPixel != 4 {
+		return nil, errImgType
+	}
+
+	pix := unsafe.Slice(bit.ImgBuf, bit.Bytewidth*bit.Height)
+	for y := 0; y < bit.Height; y++ {
+		row := pix[y*bit.Bytewidth : y*bit.Bytewidth+bit.Width*4]
+		for i := 0; i < len(row); i += 4 {
+			// BGRA to RGBA, the alpha is the padding byte
+			row[i], row[i+2], row[i+3] = row[i+2], row[i], 0xff
+		}
+	}
+
+	return &image.RGBA{
+		Pix:    pix,
+		Stride: bit.Bytewidth,
+		Rect:   image.Rect(0, 0, bit.Width, bit.Height),
+	}, nil
+}
+
+// Close close the capturer and free the buffer
+func (c *Capturer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.h == nil {
+		return errCapturerClosed
+	}
+
+	c.h.close()
+	c.h = nil
+	return nil
+}
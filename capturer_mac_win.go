@@ -0,0 +1,49 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build darwin || windows
+// +build darwin windows
+
+package robotgo
+
+import "errors"
+
+// capHandle is the CaptureScreen() capturer, keep the last bitmap
+type capHandle struct {
+	bit CBitmap
+}
+
+func openCapturer() (*capHandle, error) {
+	return &capHandle{}, nil
+}
+
+func (h *capHandle) isShm() bool {
+	return false
+}
+
+// grab capture the rect, free the last bitmap
+func (h *capHandle) grab(args ...int) (Bitmap, int, int, error) {
+	h.close()
+
+	bit, x, y := captureScreen(args...)
+	if bit == nil {
+		return Bitmap{}, 0, 0, errors.New("Capture image not found.")
+	}
+	h.bit = bit
+	return ToBitmap(h.bit), x, y, nil
+}
+
+func (h *capHandle) close() {
+	if h.bit != nil {
+		FreeBitmap(h.bit)
+		h.bit = nil
+	}
+}
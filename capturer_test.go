@@ -0,0 +1,64 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func newTestCapturer(tb testing.TB) *Capturer {
+	c, err := NewCapturer()
+	if err != nil {
+		tb.Skip("no display:", err)
+	}
+	return c
+}
+
+func TestCapturer(t *testing.T) {
+	c := newTestCapturer(t)
+	t.Log("MIT-SHM:", c.IsShm())
+
+	bit, err := c.Capture(0, 0, 100, 50)
+	tt.Nil(t, err)
+	tt.Equal(t, 100, bit.Width)
+	tt.Equal(t, 50, bit.Height)
+
+	img, err := c.CaptureRGBA(0, 0, 100, 50)
+	tt.Nil(t, err)
+	tt.Equal(t, 100, img.Bounds().Dx())
+	tt.Equal(t, uint8(0xff), img.Pix[3])
+
+	_, err = c.Capture(-1, 0, 100, 50)
+	tt.NotNil(t, err)
+
+	tt.Nil(t, c.Close())
+	_, err = c.Capture()
+	tt.Equal(t, errCapturerClosed, err)
+}
+
+func BenchmarkCaptureScreen(b *testing.B) {
+	newTestCapturer(b).Close()
+	for i := 0; i < b.N; i++ {
+		FreeBitmap(CaptureScreen(0, 0, 1280, 720))
+	}
+}
+
+func BenchmarkCapturer(b *testing.B) {
+	c := newTestCapturer(b)
+	defer c.Close()
+
+	for i := 0; i < b.N; i++ {
+		c.Capture(0, 0, 1280, 720)
+	}
+}
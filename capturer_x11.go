@@ -0,0 +1,89 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+/*
+#cgo linux LDFLAGS: -lX11 -lXext
+
+#include "screen/capturer_c.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// capHandle is the X11 capturer, the display connection and the image
+type capHandle struct {
+	c *C.MMCapturer
+	// root is the last root window size
+	root Size
+}
+
+func openCapturer() (*capHandle, error) {
+	c := C.capturer_open()
+	if c == nil {
+		return nil, errors.New("Could not open the X display.")
+	}
+	return &capHandle{c: c}, nil
+}
+
+func (h *capHandle) isShm() bool {
+	return h.c.useShm != 0
+}
+
+// grab capture the rect, return the bitmap and the origin
+func (h *capHandle) grab(args ...int) (Bitmap, int, int, error) {
+	x, y, w, hh := 0, 0, 0, 0
+	if len(args) > 3 {
+		x, y, w, hh = args[0], args[1], args[2], args[3]
+	}
+
+	// The rect outside the root is the X BadMatch error,
+	// check the root size when the rect is out of the last size
+	if len(args) < 4 || x < 0 || y < 0 || x+w > h.root.W || y+hh > h.root.H {
+		var rw, rh C.int32_t
+		C.capturer_root_size(h.c, &rw, &rh)
+		h.root = Size{W: int(rw), H: int(rh)}
+	}
+	if len(args) < 4 {
+		w, hh = h.root.W, h.root.H
+	}
+
+	if w <= 0 || hh <= 0 || x < 0 || y < 0 || x+w > h.root.W || y+hh > h.root.H {
+		return Bitmap{}, 0, 0, errors.New("The capture rect is out of the screen.")
+	}
+
+	if C.capturer_grab(h.c, C.int32_t(x), C.int32_t(y), C.int32_t(w), C.int32_t(hh)) == 0 {
+		// The screen may be resized, check the root size at the next grab
+		h.root = Size{}
+		return Bitmap{}, 0, 0, errors.New("Capture image not found.")
+	}
+
+	// The data is normalised to the 32 bits BGRA
+	return Bitmap{
+		ImgBuf:        (*uint8)(unsafe.Pointer(h.c.data)),
+		Width:         w,
+		Height:        hh,
+		Bytewidth:     int(h.c.bytewidth),
+		BitsPixel:     32,
+		BytesPerPixel: 4,
+	}, x, y, nil
+}
+
+func (h *capHandle) close() {
+	C.capturer_close(h.c)
+}
@@ -0,0 +1,406 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+)
+
+// Color is the opaque rgb color, it's the color.Color
+type Color struct {
+	R, G, B uint8
+}
+
+// HSV is the hue (0 ~ 360), saturation and value (0.0 ~ 1.0) color
+type HSV struct {
+	H, S, V float64
+}
+
+// HSL is the hue (0 ~ 360), saturation and lightness (0.0 ~ 1.0) color
+type HSL struct {
+	H, S, L float64
+}
+
+// Lab is the CIE L*a*b* color (D65)
+type Lab struct {
+	L, A, B float64
+}
+
+// DistanceFunc is the color distance of the color comparison,
+// 0.0 is the same color, it's compared with the tolerance (0.0 ~ 1.0)
+type DistanceFunc func(c1, c2 Color) float64
+
+// ColorDistance is the default color distance of all the color comparison,
+// the FindOpts, ColorOpts and WaitOpts Distance override it,
+// nil is the DistanceRGB (the fast path)
+//
+// Examples:
+//
+//	robotgo.ColorDistance = robotgo.DistanceCIEDE2000
+var ColorDistance DistanceFunc
+
+var errColorFormat = errors.New("invalid color format")
+
+// HexColor convert the CHex to Color
+func HexColor(hex CHex) Color {
+	h := uint32(hex)
+	return Color{R: uint8(h >> 16), G: uint8(h >> 8), B: uint8(h)}
+}
+
+// ToColor convert the color.Color to Color, the alpha is dropped
+func ToColor(c color.Color) Color {
+	c1 := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return Color{R: c1.R, G: c1.G, B: c1.B}
+}
+
+// ParseColor parse the color string, support the "#RRGGBB", "#RGB",
+// "RRGGBB" (the GetPixelColor() format), "0xRRGGBB", "rgb(r, g, b)"
+// and the CSS color names
+//
+// Examples:
+//
+//	c, err := robotgo.ParseColor("#ff8000")
+//	c, err = robotgo.ParseColor("rgb(255, 128, 0)")
+//	c, err = robotgo.ParseColor("orange")
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := colornames.Map[s]; ok {
+		return Color{R: c.R, G: c.G, B: c.B}, nil
+	}
+
+	if strings.HasPrefix(s, "rgb(") || strings.HasPrefix(s, "rgba(") {
+		return parseRGBFunc(s)
+	}
+
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "#"), "0x")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return Color{}, errColorFormat
+	}
+
+	h, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return Color{}, errColorFormat
+	}
+	return HexColor(CHex(h)), nil
+}
+
+// parseRGBFunc parse the "rgb(r, g, b)" or "rgba(r, g, b, a)",
+// the channel is 0 ~ 255 or the percentage
+func parseRGBFunc(s string) (Color, error) {
+	i, j := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if j < i {
+		return Color{}, errColorFormat
+	}
+
+	args := strings.FieldsFunc(s[i+1:j], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '/'
+	})
+	if len(args) != 3 && len(args) != 4 {
+		return Color{}, errColorFormat
+	}
+
+	var rgb [3]uint8
+	for k := range rgb {
+		a, scale := args[k], 1.0
+		if strings.HasSuffix(a, "%") {
+			a, scale = a[:len(a)-1], 2.55
+		}
+
+		v, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return Color{}, errColorFormat
+		}
+		rgb[k] = clampChannel(v * scale)
+	}
+	return Color{R: rgb[0], G: rgb[1], B: rgb[2]}, nil
+}
+
+func clampChannel(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(255, v))))
+}
+
+// RGBA implement the color.Color
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff}.RGBA()
+}
+
+// Hex return the CHex of the color
+func (c Color) Hex() CHex {
+	return CHex(uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B))
+}
+
+// String return the "#rrggbb" string
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// HSV convert the color to HSV
+func (c Color) HSV() HSV {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	mx, mn := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+
+	h := HSV{H: hue(r, g, b, mx, mn), V: mx}
+	if mx > 0 {
+		h.S = (mx - mn) / mx
+	}
+	return h
+}
+
+// HSL convert the color to HSL
+func (c Color) HSL() HSL {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	mx, mn := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+
+	h := HSL{H: hue(r, g, b, mx, mn), L: (mx + mn) / 2}
+	if d := mx - mn; d > 0 {
+		h.S = d / (1 - math.Abs(2*h.L-1))
+	}
+	return h
+}
+
+// hue get the hue (0 ~ 360) of the rgb (0.0 ~ 1.0)
+func hue(r, g, b, mx, mn float64) float64 {
+	d := mx - mn
+	if d == 0 {
+		return 0
+	}
+
+	var h float64
+	switch mx {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// hueColor get the color from the hue, chroma and the lightness offset
+func hueColor(h, chroma, m float64) Color {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 60
+	x := chroma * (1 - math.Abs(math.Mod(h, 2)-1))
+
+	var r, g, b float64
+	switch {
+	case h < 1:
+		r, g = chroma, x
+	case h < 2:
+		r, g = x, chroma
+	case h < 3:
+		g, b = chroma, x
+	case h < 4:
+		g, b = x, chroma
+	case h < 5:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	return Color{
+		R: clampChannel((r + m) * 255),
+		G: clampChannel((g + m) * 255),
+		B: clampChannel((b + m) * 255),
+	}
+}
+
+// Color convert the HSV to Color
+func (h HSV) Color() Color {
+	chroma := h.V * h.S
+	return hueColor(h.H, chroma, h.V-chroma)
+}
+
+// Color convert the HSL to Color
+func (h HSL) Color() Color {
+	chroma := (1 - math.Abs(2*h.L-1)) * h.S
+	return hueColor(h.H, chroma, h.L-chroma/2)
+}
+
+// The CIE constants and the D65 reference white
+const (
+	labEpsilon = 216.0 / 24389
+	labKappa   = 24389.0 / 27
+	whiteX     = 0.95047
+	whiteZ     = 1.08883
+)
+
+func toLinear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func fromLinear(c float64) uint8 {
+	if c <= 0.0031308 {
+		return clampChannel(c * 12.92 * 255)
+	}
+	return clampChannel((1.055*math.Pow(c, 1/2.4) - 0.055) * 255)
+}
+
+func labF(t float64) float64 {
+	if t > labEpsilon {
+		return math.Cbrt(t)
+	}
+	return (labKappa*t + 16) / 116
+}
+
+func labInvF(f float64) float64 {
+	if f3 := f * f * f; f3 > labEpsilon {
+		return f3
+	}
+	return (116*f - 16) / labKappa
+}
+
+// Lab convert the sRGB color to the CIE L*a*b*
+func (c Color) Lab() Lab {
+	r, g, b := toLinear(c.R), toLinear(c.G), toLinear(c.B)
+	x := 0.4124564*r + 0.3575761*g + 0.1804375*b
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := 0.0193339*r + 0.1191920*g + 0.9503041*b
+
+	fx, fy, fz := labF(x/whiteX), labF(y), labF(z/whiteZ)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// Color convert the CIE L*a*b* to the sRGB color,
+// the out of gamut color is clamped
+func (l Lab) Color() Color {
+	fy := (l.L + 16) / 116
+	x := labInvF(fy+l.A/500) * whiteX
+	y := labInvF(fy)
+	z := labInvF(fy-l.B/200) * whiteZ
+
+	return Color{
+		R: fromLinear(3.2404542*x - 1.5371385*y - 0.4985314*z),
+		G: fromLinear(-0.9692660*x + 1.8760108*y + 0.0415560*z),
+		B: fromLinear(0.0556434*x - 0.2040259*y + 1.0572252*z),
+	}
+}
+
+// DeltaE76 get the CIE76 color difference (the euclidean distance),
+// about 2.3 is the just noticeable difference
+func (l Lab) DeltaE76(o Lab) float64 {
+	dl, da, db := l.L-o.L, l.A-o.A, l.B-o.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// DeltaE2000 get the CIEDE2000 color difference
+func (l Lab) DeltaE2000(o Lab) float64 {
+	const pow25 = 6103515625.0 // 25^7
+	rad := math.Pi / 180
+
+	cBar := (math.Hypot(l.A, l.B) + math.Hypot(o.A, o.B)) / 2
+	c7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(c7/(c7+pow25)))
+
+	a1, a2 := (1+g)*l.A, (1+g)*o.A
+	c1, c2 := math.Hypot(a1, l.B), math.Hypot(a2, o.B)
+	h1, h2 := labHue(l.B, a1), labHue(o.B, a2)
+
+	dL, dC := o.L-l.L, c2-c1
+	dh := 0.0
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(dh*rad/2)
+
+	lBar, cBar1 := (l.L+o.L)/2, (c1+c2)/2
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hBar /= 2
+		case hBar < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hBar-30)*rad) + 0.24*math.Cos(2*hBar*rad) +
+		0.32*math.Cos((3*hBar+6)*rad) - 0.20*math.Cos((4*hBar-63)*rad)
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+	c7 = math.Pow(cBar1, 7)
+	rc := 2 * math.Sqrt(c7/(c7+pow25))
+	l50 := (lBar - 50) * (lBar - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*cBar1
+	sh := 1 + 0.015*cBar1*t
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	fl, fc, fh := dL/sl, dC/sc, dH/sh
+	return math.Sqrt(fl*fl + fc*fc + fh*fh + rt*fc*fh)
+}
+
+// labHue get the hue angle (0 ~ 360) of the a* b*
+func labHue(b, a float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// DistanceRGB is the rgb euclidean distance, 1.0 is the black and white
+func DistanceRGB(c1, c2 Color) float64 {
+	dr := float64(c1.R) - float64(c2.R)
+	dg := float64(c1.G) - float64(c2.G)
+	db := float64(c1.B) - float64(c2.B)
+	return math.Sqrt(dr*dr+dg*dg+db*db) / maxColorDist
+}
+
+// DistanceDE76 is the CIE76 distance, the ΔE / 100,
+// 1.0 is the black and white, it can be more than 1.0
+func DistanceDE76(c1, c2 Color) float64 {
+	return c1.Lab().DeltaE76(c2.Lab()) / 100
+}
+
+// DistanceCIEDE2000 is the CIEDE2000 distance, the ΔE / 100,
+// the tolerance 0.02 is about the just noticeable difference
+func DistanceCIEDE2000(c1, c2 Color) float64 {
+	return c1.Lab().DeltaE2000(c2.Lab()) / 100
+}
+
+// distFunc get the distance of the options or the ColorDistance,
+// nil is the rgb euclidean distance fast path
+func distFunc(d DistanceFunc) DistanceFunc {
+	if d != nil {
+		return d
+	}
+	return ColorDistance
+}
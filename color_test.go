@@ -0,0 +1,142 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestParseColor(t *testing.T) {
+	orange := Color{R: 0xff, G: 0xa5, B: 0x00}
+	for _, s := range []string{"#ffa500", "FFA500", "0xffa500", "orange",
+		" Orange ", "rgb(255, 165, 0)", "rgba(255,165,0,0.5)", "rgb(100% 64.7% 0%)"} {
+		c, err := ParseColor(s)
+		tt.Nil(t, err)
+		tt.Equal(t, orange, c)
+	}
+
+	c, err := ParseColor("#f80")
+	tt.Nil(t, err)
+	tt.Equal(t, Color{R: 0xff, G: 0x88}, c)
+
+	for _, s := range []string{"", "#ff", "#gggggg", "rgb(1, 2)", "rgb(a, b, c)", "nocolor"} {
+		_, err := ParseColor(s)
+		tt.Equal(t, errColorFormat, err)
+	}
+
+	tt.Equal(t, "#ffa500", orange.String())
+	tt.Equal(t, CHex(0xffa500), orange.Hex())
+	tt.Equal(t, orange, HexColor(0xffa500))
+	tt.Equal(t, orange, ToColor(color.RGBA{0xff, 0xa5, 0x00, 0xff}))
+	tt.Equal(t, color.RGBA{0xff, 0xa5, 0x00, 0xff}, color.RGBAModel.Convert(orange))
+}
+
+func TestColorSpace(t *testing.T) {
+	h := Color{R: 0xff, G: 0x80}.HSV()
+	tt.Equal(t, 30, int(math.Round(h.H)))
+	tt.Equal(t, 1.0, h.S)
+	tt.Equal(t, 1.0, h.V)
+
+	l := Color{R: 0x80, G: 0x80, B: 0x80}.HSL()
+	tt.Equal(t, 0.0, l.S)
+	tt.True(t, math.Abs(l.L-0.502) < 0.001)
+
+	lab := Color{R: 0xff, G: 0xff, B: 0xff}.Lab()
+	tt.True(t, math.Abs(lab.L-100) < 0.01)
+	tt.True(t, math.Abs(lab.A) < 0.01 && math.Abs(lab.B) < 0.01)
+
+	for _, c := range []Color{{}, {0xff, 0xff, 0xff}, {0x12, 0x34, 0x56},
+		{0xff, 0xa5, 0x00}, {0x00, 0xc8, 0x10}, {0x80, 0x00, 0xff}} {
+		tt.Equal(t, c, c.HSV().Color())
+		tt.Equal(t, c, c.HSL().Color())
+		tt.Equal(t, c, c.Lab().Color())
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	// The CIEDE2000 test data of the Sharma, Wu and Dalal paper
+	data := []struct {
+		l1, l2 Lab
+		de     float64
+	}{
+		{Lab{50, 2.6772, -79.7751}, Lab{50, 0, -82.7485}, 2.0425},
+		{Lab{50, 0, 0}, Lab{50, -1, 2}, 2.3669},
+		{Lab{50, 2.5, 0}, Lab{73, 25, -18}, 27.1492},
+		{Lab{50, 2.5, 0}, Lab{50, 3.1736, 0.5854}, 1.0000},
+		{Lab{60.2574, -34.0099, 36.2677}, Lab{60.4626, -34.1751, 39.4387}, 1.2644},
+		{Lab{22.7233, 20.0904, -46.6940}, Lab{23.0331, 14.9730, -42.5619}, 2.0373},
+		{Lab{2.0776, 0.0795, -1.1350}, Lab{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+	for _, d := range data {
+		tt.True(t, math.Abs(d.l1.DeltaE2000(d.l2)-d.de) < 1e-4)
+		tt.True(t, math.Abs(d.l2.DeltaE2000(d.l1)-d.de) < 1e-4)
+	}
+
+	tt.Equal(t, 5.0, Lab{50, 0, 0}.DeltaE76(Lab{50, 3, 4}))
+	black, white := Color{}, Color{0xff, 0xff, 0xff}
+	tt.True(t, math.Abs(DistanceRGB(black, white)-1) < 0.01)
+	tt.True(t, math.Abs(DistanceDE76(black, white)-1) < 0.01)
+	tt.True(t, math.Abs(DistanceCIEDE2000(black, white)-1) < 0.01)
+	tt.Equal(t, 0.0, DistanceCIEDE2000(white, white))
+}
+
+func TestColorDistance(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	// The dark blue is closer to the black by the rgb, but farther by the CIEDE2000
+	src.Set(5, 5, color.RGBA{0x00, 0x00, 0x28, 0xff})
+	src.Set(15, 5, color.RGBA{0x28, 0x28, 0x28, 0xff})
+
+	n, err := CountColor(0x000000, src, ColorOpts{Tolerance: 0.01})
+	tt.Nil(t, err)
+	tt.Equal(t, 198, n)
+
+	n, err = CountColor(0x000000, src, ColorOpts{Tolerance: 0.12})
+	tt.Nil(t, err)
+	tt.Equal(t, 199, n)
+	pt, err := FindColor(0x000000, src, ColorOpts{Tolerance: 0.12, Order: ScanCenter})
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 10, Y: 5}, pt)
+
+	n, err = CountColor(0x000000, src, ColorOpts{Tolerance: 0.12, Distance: DistanceCIEDE2000})
+	tt.Nil(t, err)
+	tt.Equal(t, 199, n)
+	arr0, err := FindEveryColor(0x000028, src, ColorOpts{Tolerance: 0.12, Distance: DistanceCIEDE2000})
+	tt.Nil(t, err)
+	tt.Equal(t, []Point{{X: 5, Y: 5}}, arr0)
+
+	tpl := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	tpl.Set(1, 1, color.RGBA{0x00, 0x00, 0x28, 0xff})
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if x != 1 || y != 1 {
+				tpl.Set(x, y, color.RGBA{0, 0, 0, 0xff})
+			}
+		}
+	}
+
+	arr, err := FindAllBitmaps(tpl, src, FindOpts{Tolerance: 0.1, Distance: DistanceCIEDE2000})
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, Point{X: 4, Y: 4}, arr[0].Point)
+
+	ColorDistance = DistanceCIEDE2000
+	defer func() { ColorDistance = nil }()
+	m, err := FindBitmap(tpl, src, 0.1)
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 4, Y: 4}, m.Point)
+	tt.True(t, m.Score > 0.99)
+}
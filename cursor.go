@@ -0,0 +1,103 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/draw"
+	"unsafe"
+)
+
+// GetCursorImage get the current mouse cursor image and the hotspot,
+// the hotspot is the click point offset in the image (Linux)
+//
+// Examples:
+//
+//	img, hot, err := robotgo.GetCursorImage()
+func GetCursorImage() (image.Image, Point, error) {
+	img, hot, _, err := cursorImage()
+	if err != nil {
+		return nil, Point{}, err
+	}
+	return img, hot, nil
+}
+
+// DrawCursor draw the mouse cursor into the image at the mouse location,
+// (x, y) is the image origin on the screen
+//
+// Examples:
+//
+//	img, _ := robotgo.Capture(10, 10, 300, 300)
+//	robotgo.DrawCursor(img, 10, 10)
+func DrawCursor(img draw.Image, x, y int) error {
+	cur, hot, pos, err := cursorImage()
+	if err != nil {
+		return err
+	}
+
+	drawCursor(img, cur, pos.X-hot.X-x, pos.Y-hot.Y-y)
+	return nil
+}
+
+// drawCursor draw the cursor image at the (ox, oy) of the image
+func drawCursor(img draw.Image, cur *image.RGBA, ox, oy int) {
+	r := cur.Bounds().Add(image.Pt(ox, oy)).Add(img.Bounds().Min)
+	draw.Draw(img, r, cur, image.Point{}, draw.Over)
+}
+
+// drawCursorBitmap draw the mouse cursor into the 32 bit BGRA bitmap,
+// (x, y) is the bitmap origin on the screen
+func drawCursorBitmap(bit Bitmap, x, y int) error {
+	cur, hot, pos, err := cursorImage()
+	if err != nil {
+		return err
+	}
+
+	blendCursor(bit, cur, pos.X-hot.X-x, pos.Y-hot.Y-y)
+	return nil
+}
+
+// blendCursor blend the cursor image at the (ox, oy) of the BGRA bitmap
+func blendCursor(bit Bitmap, cur *image.RGBA, ox, oy int) {
+	if bit.ImgBuf == nil || bit.BytesPerPixel != 4 {
+		return
+	}
+
+	buf := unsafe.Slice(bit.ImgBuf, bit.Bytewidth*bit.Height)
+	cw, ch := cur.Bounds().Dx(), cur.Bounds().Dy()
+
+	for cy := 0; cy < ch; cy++ {
+		by := oy + cy
+		if by < 0 || by >= bit.Height {
+			continue
+		}
+
+		for cx := 0; cx < cw; cx++ {
+			bx := ox + cx
+			if bx < 0 || bx >= bit.Width {
+				continue
+			}
+
+			s := cur.Pix[cy*cur.Stride+cx*4:]
+			a := uint32(s[3])
+			if a == 0 {
+				continue
+			}
+
+			d := buf[by*bit.Bytewidth+bx*4:]
+			// The cursor is premultiplied, the bitmap is BGRA
+			d[0] = uint8(uint32(s[2]) + uint32(d[0])*(255-a)/255)
+			d[1] = uint8(uint32(s[1]) + uint32(d[1])*(255-a)/255)
+			d[2] = uint8(uint32(s[0]) + uint32(d[2])*(255-a)/255)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build darwin || windows
+// +build darwin windows
+
+package robotgo
+
+import (
+	"errors"
+	"image"
+)
+
+func cursorImage() (*image.RGBA, Point, Point, error) {
+	return nil, Point{}, Point{}, errors.New("GetCursorImage is only supported on Linux")
+}
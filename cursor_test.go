@@ -0,0 +1,85 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+// testCursor make the 4x4 cursor, the opaque red top row,
+// the half transparent white second row and the transparent rest
+func testCursor() *image.RGBA {
+	cur := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		cur.SetRGBA(x, 0, color.RGBA{0xff, 0, 0, 0xff})
+		// premultiplied
+		cur.SetRGBA(x, 1, color.RGBA{0x80, 0x80, 0x80, 0x80})
+	}
+	return cur
+}
+
+func TestDrawCursor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.SetRGBA(3, 3, color.RGBA{0, 0, 0xff, 0xff})
+
+	drawCursor(img, testCursor(), 2, 2)
+	tt.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, img.RGBAAt(2, 2))
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(2, 3))
+	tt.Equal(t, color.RGBA{0x80, 0x80, 0xff, 0xff}, img.RGBAAt(3, 3))
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(2, 4))
+
+	// The cursor is clipped by the sub image
+	sub := img.SubImage(image.Rect(5, 5, 10, 10)).(*image.RGBA)
+	drawCursor(sub, testCursor(), 3, -1)
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(8, 4))
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, img.RGBAAt(8, 5))
+}
+
+func TestBlendCursor(t *testing.T) {
+	// The 10x3 BGRA bitmap with the padding bytes
+	buf := make([]uint8, 48*3)
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	bit := Bitmap{ImgBuf: &buf[0], Width: 10, Height: 3, Bytewidth: 48,
+		BitsPixel: 32, BytesPerPixel: 4}
+	px := func(x, y int) []uint8 {
+		return buf[y*48+x*4 : y*48+x*4+3]
+	}
+	px(1, 2)[0] = 0
+
+	blendCursor(bit, testCursor(), 8, 1)
+	// The red is the BGRA 0, 0, 0xff
+	tt.Equal(t, []uint8{0, 0, 0xff}, px(8, 1))
+	tt.Equal(t, []uint8{0, 0, 0xff}, px(9, 1))
+	tt.Equal(t, []uint8{0xff, 0xff, 0xff}, px(9, 2))
+	// The padding is not touched
+	tt.Equal(t, uint8(0xff), buf[48+40])
+
+	blendCursor(bit, testCursor(), 0, 1)
+	tt.Equal(t, []uint8{0x80, 0xff, 0xff}, px(1, 2))
+	tt.Equal(t, []uint8{0xff, 0xff, 0xff}, px(0, 0))
+
+	// The cursor is clipped at the left
+	blendCursor(bit, testCursor(), -2, 0)
+	tt.Equal(t, []uint8{0, 0, 0xff}, px(1, 0))
+	tt.Equal(t, []uint8{0xff, 0xff, 0xff}, px(2, 0))
+
+	blendCursor(Bitmap{}, testCursor(), 0, 0)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"image"
+	"sync"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/xfixes"
+)
+
+var (
+	xfixesOnce sync.Once
+	xfixesErr  error
+)
+
+// xfixesConn get the shared xgb connection with the XFixes extension ready
+func xfixesConn() (*xgb.Conn, error) {
+	c, err := getXConn()
+	if err != nil {
+		return nil, err
+	}
+
+	xfixesOnce.Do(func() {
+		if xfixesErr = xfixes.Init(c); xfixesErr != nil {
+			return
+		}
+		// The XFixes request needs the version negotiate first
+		_, xfixesErr = xfixes.QueryVersion(c, 5, 0).Reply()
+	})
+	return c, xfixesErr
+}
+
+// cursorImage get the cursor image, hotspot and the screen position
+func cursorImage() (*image.RGBA, Point, Point, error) {
+	c, err := xfixesConn()
+	if err != nil {
+		return nil, Point{}, Point{}, err
+	}
+
+	r, err := xfixes.GetCursorImage(c).Reply()
+	if err != nil {
+		return nil, Point{}, Point{}, err
+	}
+
+	w, h := int(r.Width), int(r.Height)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	// The cursor image is the premultiplied ARGB, same as the image.RGBA
+	for i := 0; i < w*h && i < len(r.CursorImage); i++ {
+		p := r.CursorImage[i]
+		img.Pix[i*4] = uint8(p >> 16)
+		img.Pix[i*4+1] = uint8(p >> 8)
+		img.Pix[i*4+2] = uint8(p)
+		img.Pix[i*4+3] = uint8(p >> 24)
+	}
+
+	hot := Point{X: int(r.Xhot), Y: int(r.Yhot)}
+	pos := Point{X: int(r.X), Y: int(r.Y)}
+	return img, hot, pos, nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"time"
+	"unsafe"
+)
+
+// diffBlock is the block size of the changed rects,
+// the changes closer than it are merged into one rect
+const diffBlock = 8
+
+var errDiffSize = errors.New("the bitmaps size is not the same")
+
+// ScreenDiff compare two captures and return the changed rects,
+// the pixels within the threshold (0.0 ~ 1.0) are not changed,
+// 0 is any change, the rects are in the bitmap coordinates,
+// the color distance is the dist or the ColorDistance
+//
+// Examples:
+//
+//	before := robotgo.CaptureScreen()
+//	defer robotgo.FreeBitmap(before)
+//	robotgo.Click()
+//	robotgo.MilliSleep(100)
+//	after := robotgo.CaptureScreen()
+//	defer robotgo.FreeBitmap(after)
+//
+//	rects, err := robotgo.ScreenDiff(robotgo.ToBitmap(before),
+//		robotgo.ToBitmap(after), 0.05)
+func ScreenDiff(before, after Bitmap, threshold float64, dist ...DistanceFunc) ([]Rect, error) {
+	if before.Width != after.Width || before.Height != after.Height {
+		return nil, errDiffSize
+	}
+	if before.ImgBuf == nil || after.ImgBuf == nil ||
+		before.BytesPerPixel < 3 || after.BytesPerPixel < 3 {
+		return nil, errImgType
+	}
+
+	b1 := unsafe.Slice(before.ImgBuf, before.Bytewidth*before.Height)
+	b2 := unsafe.Slice(after.ImgBuf, after.Bytewidth*after.Height)
+	bpp1, bpp2 := int(before.BytesPerPixel), int(after.BytesPerPixel)
+	tol := threshold * maxColorDist
+	tol2 := int32(tol * tol)
+
+	var df DistanceFunc
+	if len(dist) > 0 {
+		df = dist[0]
+	}
+	df = distFunc(df)
+
+	return diffRects(before.Width, before.Height, func(x, y int) bool {
+		// The bitmap is BGR(A), the padding byte is ignored
+		p1 := b1[y*before.Bytewidth+x*bpp1:]
+		p2 := b2[y*after.Bytewidth+x*bpp2:]
+		if df != nil {
+			return df(Color{R: p1[2], G: p1[1], B: p1[0]},
+				Color{R: p2[2], G: p2[1], B: p2[0]}) > threshold
+		}
+
+		d0 := int32(p1[0]) - int32(p2[0])
+		d1 := int32(p1[1]) - int32(p2[1])
+		d2 := int32(p1[2]) - int32(p2[2])
+		return d0*d0+d1*d1+d2*d2 > tol2
+	}), nil
+}
+
+// diffRects group the changed pixels by the blocks,
+// return the bounding rects of the connected changed blocks
+func diffRects(w, h int, changed func(x, y int) bool) []Rect {
+	bw, bh := (w+diffBlock-1)/diffBlock, (h+diffBlock-1)/diffBlock
+	// The changed pixels bounds of every block
+	blocks := make([]image.Rectangle, bw*bh)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !changed(x, y) {
+				continue
+			}
+
+			i := (y/diffBlock)*bw + x/diffBlock
+			blocks[i] = blocks[i].Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+
+	var (
+		arr   []Rect
+		stack []int
+	)
+	seen := make([]bool, len(blocks))
+	for i := range blocks {
+		if seen[i] || blocks[i].Empty() {
+			continue
+		}
+
+		seen[i] = true
+		stack = append(stack[:0], i)
+		box := blocks[i]
+		for len(stack) > 0 {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			box = box.Union(blocks[j])
+
+			bx, by := j%bw, j/bw
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := bx+dx, by+dy
+					if nx < 0 || ny < 0 || nx >= bw || ny >= bh {
+						continue
+					}
+
+					k := ny*bw + nx
+					if !seen[k] && !blocks[k].Empty() {
+						seen[k] = true
+						stack = append(stack, k)
+					}
+				}
+			}
+		}
+
+		arr = append(arr, Rect{
+			Point{X: box.Min.X, Y: box.Min.Y},
+			Size{W: box.Dx(), H: box.Dy()},
+		})
+	}
+	return arr
+}
+
+// WaitForStable capture the region until no pixel changes for the quiet (ms),
+// return the *TimeoutError with the last frame when timeout (ms),
+// the region is nil (the screen) or a Rect, the same as FindBitmap()
+//
+// robotgo.WaitForStable(region interface{}, quiet, timeout, interval int)
+//
+// Examples:
+//
+//	robotgo.Click()
+//	err := robotgo.WaitForStable(nil, 500, 10000)
+func WaitForStable(region interface{}, quiet, timeout int, interval ...int) error {
+	tm := 100
+	if len(interval) > 0 {
+		tm = interval[0]
+	}
+	tm = max(min(tm, quiet), 1)
+
+	var (
+		last   *image.RGBA
+		stable time.Time
+	)
+	opt := WaitOpts{Timeout: timeout, Interval: tm}
+	return waitFor("WaitForStable", region, opt, func(src *image.RGBA, o Point) bool {
+		now := time.Now()
+		if last == nil || !bytes.Equal(last.Pix, src.Pix) {
+			last, stable = src, now
+			return false
+		}
+		return now.Sub(stable) >= time.Duration(quiet)*time.Millisecond
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+// testBitmap make a gray BGR(A) bitmap with the padding row bytes
+func testBitmap(w, h, bpp int) (Bitmap, []byte) {
+	stride := w*bpp + 6
+	buf := make([]byte, stride*h)
+	for i := range buf {
+		buf[i] = 0x80
+	}
+
+	return Bitmap{ImgBuf: &buf[0], Width: w, Height: h, Bytewidth: stride,
+		BitsPixel: uint8(bpp * 8), BytesPerPixel: uint8(bpp)}, buf
+}
+
+func TestScreenDiff(t *testing.T) {
+	for _, bpp := range []int{3, 4} {
+		b1, _ := testBitmap(100, 50, bpp)
+		b2, buf := testBitmap(100, 50, bpp)
+		set := func(x, y int, v byte) {
+			buf[y*b2.Bytewidth+x*bpp+1] = v
+		}
+
+		rects, err := ScreenDiff(b1, b2, 0)
+		tt.Nil(t, err)
+		tt.Equal(t, 0, len(rects))
+
+		// The text changed and a small noise
+		for x := 10; x < 30; x += 3 {
+			set(x, 5, 0)
+			set(x, 7, 0)
+		}
+		set(90, 45, 0x82)
+		// The row padding is not the pixels
+		buf[3*b2.Bytewidth-1] = 0
+
+		rects, err = ScreenDiff(b1, b2, 0)
+		tt.Nil(t, err)
+		tt.Equal(t, 2, len(rects))
+		tt.Equal(t, Rect{Point{X: 10, Y: 5}, Size{W: 19, H: 3}}, rects[0])
+		tt.Equal(t, Rect{Point{X: 90, Y: 45}, Size{W: 1, H: 1}}, rects[1])
+
+		rects, _ = ScreenDiff(b1, b2, 0.05)
+		tt.Equal(t, 1, len(rects))
+		rects, _ = ScreenDiff(b1, b2, 0.05, DistanceRGB)
+		tt.Equal(t, 1, len(rects))
+		rects, _ = ScreenDiff(b1, b2, 0.05, func(c1, c2 Color) float64 {
+			if c1 != c2 {
+				return 1
+			}
+			return 0
+		})
+		tt.Equal(t, 2, len(rects))
+	}
+
+	b1, _ := testBitmap(10, 10, 4)
+	b2, _ := testBitmap(10, 11, 4)
+	_, err := ScreenDiff(b1, b2, 0)
+	tt.NotNil(t, err)
+}
+
+func TestWaitForStable(t *testing.T) {
+	src := testScreen(200, 100)
+	tt.Nil(t, WaitForStable(src, 30, 500, 10))
+
+	err := WaitForStable(src, 100, 30, 10)
+	_, ok := err.(*TimeoutError)
+	tt.True(t, ok)
+}
@@ -128,6 +128,12 @@ Keys are supported:
 |		| robotgo.Bitmap -> *image.RGBA | robotgo.ToRGBAGo()
 |	*	| robotgo.CBitmap -> C.MMBitmapRef | robotgo.ToMMBitmapRef()
 |		| robotgo.CBitmap -> robotgo.Bitmap | robotgo.ToBitmap()
+|		| robotgo.CBitmap -> *robotgo.GoBitmap | robotgo.CBitmapToGo()
+|		| robotgo.CBitmap -> *robotgo.CBitmapRef | robotgo.NewCBitmapRef()
+|		| robotgo.Bitmap -> *robotgo.GoBitmap | robotgo.ToGoBitmap()
+|		| *robotgo.GoBitmap -> robotgo.Bitmap | GoBitmap.Bitmap()
+|		| *robotgo.GoBitmap -> *robotgo.CBitmapRef | GoBitmap.CBitmap()
+|		| image.Image -> *robotgo.GoBitmap | robotgo.ImgToGoBitmap()
 |		| robotgo.CBitmap -> image.Image | robotgo.ToImage()
 |		| robotgo.CBitmap -> *image.RGBA | robotgo.ToRGBA()
 |	*	| C.MMBitmapRef -> robotgo.CBitmap | robotgo.CBitmap()
@@ -0,0 +1,604 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// DefaultTolerance the default color tolerance of the image search,
+// same as the MMRGBColorSimilarToColor() (0.0 ~ 1.0)
+const DefaultTolerance = 0.05
+
+// maxColorDist the max euclidean distance of two rgb colors
+const maxColorDist = 442.0
+
+var (
+	// ErrNotFound the image or color is not found
+	ErrNotFound = errors.New("not found")
+	errImgType  = errors.New("unsupported image type")
+)
+
+// Match is the image search result
+type Match struct {
+	Rect
+	// Score is the similarity score (0.0 ~ 1.0)
+	Score float64
+	// Scale is the template scale of the match
+	Scale float64
+}
+
+// FindOpts is the FindAllBitmaps options, the zero value is the default
+type FindOpts struct {
+	// Tolerance is the color tolerance (0.0 ~ 1.0),
+	// default is the DefaultTolerance, use a tiny value for the exact match
+	Tolerance float64
+	// MaxResults is the max matches number, default is unlimited
+	MaxResults int
+	// Overlap is the max overlap (intersection over union)
+	// of two matches, default is 0.3
+	Overlap float64
+
+	// MinScale and MaxScale is the template scale range, default is 1.0,
+	// e.g. 0.8 ~ 1.5 to search the 1.0 template on the 1.25 screen
+	MinScale, MaxScale float64
+	// ScaleStep is the scale pyramid step, default is 0.05
+	ScaleStep float64
+	// Outliers is the max ratio of the template pixels out of the tolerance,
+	// default is 0, the resampled template is 0.1 at least for the edges
+	Outliers float64
+	// Distance is the color distance, default is the ColorDistance
+	Distance DistanceFunc
+}
+
+// tplPixel is the template opaque pixel
+type tplPixel struct {
+	x, y    int
+	r, g, b int32
+}
+
+// template is the prepared search template
+type template struct {
+	w, h int
+	pix  []tplPixel
+	// miss is the number of the pixels allowed out of the tolerance
+	miss int
+	// dist is the color distance, nil is the rgb euclidean distance
+	dist DistanceFunc
+}
+
+// FindBitmap find the template image in the region,
+// return the best match location and score, or the ErrNotFound.
+//
+// The template can be the Bitmap, CBitmap or image.Image,
+// the transparent pixels of the image.Image template are ignored.
+// The region can be nil (the screen), a Rect (a live screen region)
+// or a capture (Bitmap, CBitmap or image.Image),
+// the tolerance is 0.0 ~ 1.0, default is the DefaultTolerance,
+// the color distance is the ColorDistance.
+// Pass the FindOpts to set the Tolerance and the Distance of the call,
+// the other FindOpts are used by the FindAllBitmaps() only.
+//
+// robotgo.FindBitmap(tpl, region interface{}, tolerance float64 | opts FindOpts)
+//
+// Examples:
+//
+//	tpl, _ := robotgo.Read("btn.png")
+//	m, err := robotgo.FindBitmap(tpl, nil)
+//	if err == nil {
+//		robotgo.Move(robotgo.ScreenToMove(m.X+m.W/2, m.Y+m.H/2))
+//	}
+//
+//	m, err = robotgo.FindBitmap(tpl, robotgo.Rect{
+//		Point: robotgo.Point{X: 0, Y: 0}, Size: robotgo.Size{W: 800, H: 600}}, 0.1)
+//
+//	m, err = robotgo.FindBitmap(tpl, nil, robotgo.FindOpts{Distance: robotgo.DistanceDE76})
+func FindBitmap(tpl, region interface{}, args ...interface{}) (Match, error) {
+	var opt FindOpts
+	if len(args) > 0 {
+		switch v := args[0].(type) {
+		case float64:
+			opt.Tolerance = v
+		case int:
+			opt.Tolerance = float64(v)
+		case FindOpts:
+			opt = v
+		default:
+			return Match{}, errors.New("the argument must be the tolerance or the FindOpts")
+		}
+	}
+	opt = opt.withDefault()
+
+	img, useAlpha, err := templateRGBA(tpl)
+	if err != nil {
+		return Match{}, err
+	}
+	t := rgbaTemplate(img, useAlpha)
+	t.dist = distFunc(opt.Distance)
+
+	src, o, err := regionRGBA(region)
+	if err != nil {
+		return Match{}, err
+	}
+
+	m, ok := findBest(src, t, opt.Tolerance)
+	if !ok {
+		debugFind("FindBitmap", region, src, o, nil)
+		return Match{}, ErrNotFound
+	}
+
+	m.X += o.X
+	m.Y += o.Y
+	debugFind("FindBitmap", region, src, o, func(a *Annotator) {
+		a.Rect(m.Rect, annotMatch, fmt.Sprintf("%.3f", m.Score))
+	})
+	return m, nil
+}
+
+// regionRGBA get the region image and the origin on the screen
+func regionRGBA(region interface{}) (*image.RGBA, Point, error) {
+	switch r := region.(type) {
+	case nil:
+		// Same as the CaptureScreen() origin
+		o := Point{}
+		if runtime.GOOS != "darwin" {
+			o = GetScreenRect(displayIdx()).Point
+		}
+
+		img, err := CaptureImg()
+		if err != nil {
+			return nil, Point{}, err
+		}
+		return img.(*image.RGBA), o, nil
+	case Rect:
+		img, err := CaptureImg(r.X, r.Y, r.W, r.H)
+		if err != nil {
+			return nil, Point{}, err
+		}
+		return img.(*image.RGBA), r.Point, nil
+	case *Rect:
+		return regionRGBA(*r)
+	}
+
+	img, err := toRGBA(region)
+	return img, Point{}, err
+}
+
+// toRGBA convert the Bitmap, CBitmap or image.Image to *image.RGBA
+func toRGBA(v interface{}) (*image.RGBA, error) {
+	switch b := v.(type) {
+	case CBitmap:
+		if b == nil {
+			return nil, errImgType
+		}
+		return ToRGBA(b), nil
+	case Bitmap:
+		if b.ImgBuf == nil {
+			return nil, errImgType
+		}
+		return ToRGBAGo(b), nil
+	case *Bitmap:
+		return toRGBA(*b)
+	case *image.RGBA:
+		if b.Rect.Min == (image.Point{}) {
+			return b, nil
+		}
+	}
+
+	img, ok := v.(image.Image)
+	if !ok || img == nil {
+		return nil, errImgType
+	}
+
+	rect := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst, nil
+}
+
+// templateRGBA get the template image, only the image.Image alpha is used,
+// the Bitmap and CBitmap alpha is the screen padding byte
+func templateRGBA(tpl interface{}) (*image.RGBA, bool, error) {
+	img, err := toRGBA(tpl)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch tpl.(type) {
+	case CBitmap, Bitmap, *Bitmap:
+		return img, false, nil
+	}
+	return img, true, nil
+}
+
+func rgbaTemplate(img *image.RGBA, useAlpha bool) template {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	t := template{w: w, h: h}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			p := img.Pix[y*img.Stride+x*4:]
+			if useAlpha && p[3] < 0x80 {
+				continue
+			}
+			t.pix = append(t.pix, tplPixel{x: x, y: y,
+				r: int32(p[0]), g: int32(p[1]), b: int32(p[2])})
+		}
+	}
+
+	if len(t.pix) == 0 && useAlpha {
+		// All transparent, it's the image without the alpha
+		return rgbaTemplate(img, false)
+	}
+
+	t.pix = spread(t.pix)
+	return t
+}
+
+// spread reorder the pixels to check the far apart pixels first,
+// the mismatch position can be rejected after a few pixels
+func spread(pix []tplPixel) []tplPixel {
+	n := len(pix)
+	step := 7919
+	// The step must be coprime to n to visit all the pixels
+	for n > 1 && gcd(step, n) != 1 {
+		step += 2
+	}
+
+	res := make([]tplPixel, n)
+	for i := 0; i < n; i++ {
+		res[i] = pix[(i*step)%n]
+	}
+	return res
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// findBest find the best match of the template in the src
+func findBest(src *image.RGBA, t template, tol float64) (Match, bool) {
+	var (
+		best  Match
+		found bool
+		mu    sync.Mutex
+	)
+
+	scanRGBA(src, t, tol, func(x, y int, score float64) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		better := score > best.Score || (score == best.Score &&
+			(y < best.Y || (y == best.Y && x < best.X)))
+		if !found || better {
+			best = Match{Rect{Point{X: x, Y: y}, Size{W: t.w, H: t.h}}, score, 1}
+			found = true
+		}
+	})
+
+	return best, found
+}
+
+// FindAllBitmaps find all the template image matches in the region,
+// sorted by the score, the overlapped matches are suppressed,
+// return the ErrNotFound if there is no match.
+//
+// Set the opts MinScale and MaxScale to search the scaled template,
+// the same as FindBitmap() for the region and the template.
+//
+// robotgo.FindAllBitmaps(tpl, region interface{}, opts FindOpts)
+//
+// Examples:
+//
+//	tpl, _ := robotgo.Read("checkbox.png")
+//	arr, err := robotgo.FindAllBitmaps(tpl, nil, robotgo.FindOpts{
+//		MaxResults: 20, MinScale: 0.75, MaxScale: 2})
+//	for _, m := range arr {
+//		fmt.Println(m.Rect, m.Scale, m.Score)
+//	}
+func FindAllBitmaps(tpl, region interface{}, opts ...FindOpts) ([]Match, error) {
+	var opt FindOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt = opt.withDefault()
+
+	img, useAlpha, err := templateRGBA(tpl)
+	if err != nil {
+		return nil, err
+	}
+
+	src, o, err := regionRGBA(region)
+	if err != nil {
+		return nil, err
+	}
+
+	set := localMax{overlap: opt.Overlap}
+	for _, scale := range opt.scales() {
+		t := rgbaTemplate(scaleRGBA(img, scale), useAlpha)
+		t.miss = int(opt.outliers(scale) * float64(len(t.pix)))
+		t.dist = distFunc(opt.Distance)
+		scanRGBA(src, t, opt.Tolerance, func(x, y int, score float64) {
+			set.add(Match{Rect{Point{X: x + o.X, Y: y + o.Y},
+				Size{W: t.w, H: t.h}}, score, scale})
+		})
+	}
+
+	arr := suppress(set.arr, opt.Overlap, opt.MaxResults)
+	if len(arr) == 0 {
+		debugFind("FindAllBitmaps", region, src, o, nil)
+		return nil, ErrNotFound
+	}
+
+	debugFind("FindAllBitmaps", region, src, o, func(a *Annotator) {
+		for _, m := range arr {
+			a.Rect(m.Rect, annotMatch, fmt.Sprintf("%.3f", m.Score))
+		}
+	})
+	return arr, nil
+}
+
+func (opt FindOpts) withDefault() FindOpts {
+	if opt.Tolerance <= 0 {
+		opt.Tolerance = DefaultTolerance
+	}
+	if opt.Overlap <= 0 {
+		opt.Overlap = 0.3
+	}
+	if opt.MinScale <= 0 {
+		opt.MinScale = 1
+	}
+	if opt.MaxScale < opt.MinScale {
+		opt.MaxScale = opt.MinScale
+	}
+	if opt.ScaleStep <= 0 {
+		opt.ScaleStep = 0.05
+	}
+	return opt
+}
+
+func (opt FindOpts) outliers(scale float64) float64 {
+	if scale != 1 {
+		return math.Max(opt.Outliers, 0.1)
+	}
+	return opt.Outliers
+}
+
+// scales get the scale pyramid, from the MinScale to the MaxScale
+func (opt FindOpts) scales() []float64 {
+	var arr []float64
+	n := int(math.Floor((opt.MaxScale-opt.MinScale)/opt.ScaleStep + 1e-9))
+	for i := 0; i <= n; i++ {
+		arr = append(arr, opt.MinScale+float64(i)*opt.ScaleStep)
+	}
+	return arr
+}
+
+// scaleRGBA resize the template image by the scale
+func scaleRGBA(img *image.RGBA, scale float64) *image.RGBA {
+	if scale == 1 {
+		return img
+	}
+
+	w := int(math.Round(float64(img.Rect.Dx()) * scale))
+	h := int(math.Round(float64(img.Rect.Dy()) * scale))
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.BiLinear.Scale(dst, dst.Rect, img, img.Rect, xdraw.Src, nil)
+	return dst
+}
+
+// localMax keep the local maximum matches during the scan, the match
+// is dropped if an overlapped match is better, so only the non-overlapped
+// matches are buffered rather than all the positions over the threshold
+type localMax struct {
+	mu      sync.Mutex
+	overlap float64
+	arr     []Match
+}
+
+func (l *localMax) add(m Match) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, r := range l.arr {
+		if iou(m.Rect, r.Rect) > l.overlap && !betterMatch(m, r) {
+			return
+		}
+	}
+
+	// Replace the overlapped worse matches
+	n := 0
+	for _, r := range l.arr {
+		if iou(m.Rect, r.Rect) <= l.overlap {
+			l.arr[n] = r
+			n++
+		}
+	}
+	l.arr = append(l.arr[:n], m)
+}
+
+// betterMatch return true if the a is better than the b,
+// the higher score, then the top-left one
+func betterMatch(a, b Match) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.Y != b.Y {
+		return a.Y < b.Y
+	}
+	return a.X < b.X
+}
+
+// suppress sort the matches by the score and drop the overlapped matches,
+// the non-maximum suppression
+func suppress(arr []Match, overlap float64, limit int) []Match {
+	sort.Slice(arr, func(i, j int) bool {
+		return betterMatch(arr[i], arr[j])
+	})
+
+	var res []Match
+	for _, m := range arr {
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+
+		keep := true
+		for _, r := range res {
+			if iou(m.Rect, r.Rect) > overlap {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// iou get the intersection over union of two rects
+func iou(a, b Rect) float64 {
+	w := min(a.X+a.W, b.X+b.W) - max(a.X, b.X)
+	h := min(a.Y+a.H, b.Y+b.H) - max(a.Y, b.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+
+	in := float64(w * h)
+	return in / (float64(a.W*a.H+b.W*b.H) - in)
+}
+
+// scanRGBA check every position of the src in parallel,
+// call the fn with the matched position and score
+func scanRGBA(src *image.RGBA, t template, tol float64, fn func(x, y int, score float64)) {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	if t.w <= 0 || t.h <= 0 || t.w > sw || t.h > sh {
+		return
+	}
+
+	offs := make([]int, len(t.pix))
+	for i, p := range t.pix {
+		offs[i] = p.y*src.Stride + p.x*4
+	}
+
+	tol2 := int32(tol * maxColorDist * tol * maxColorDist)
+	rows := sh - t.h + 1
+	workers := runtime.NumCPU()
+	if workers > rows {
+		workers = rows
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for y := w; y < rows; y += workers {
+				for x := 0; x <= sw-t.w; x++ {
+					base := y*src.Stride + x*4
+					if t.dist != nil {
+						if matchDist(src.Pix, base, offs, t, tol) {
+							fn(x, y, scoreDist(src.Pix, base, offs, t))
+						}
+						continue
+					}
+
+					if matchAt(src.Pix, base, offs, t.pix, tol2, t.miss) {
+						fn(x, y, scoreAt(src.Pix, base, offs, t.pix))
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// matchAt check the template pixels are within the tolerance,
+// except the miss number of the pixels
+func matchAt(pix []uint8, base int, offs []int, tp []tplPixel, tol2 int32, miss int) bool {
+	for i, off := range offs {
+		p := pix[base+off : base+off+3]
+		dr := int32(p[0]) - tp[i].r
+		dg := int32(p[1]) - tp[i].g
+		db := int32(p[2]) - tp[i].b
+		if dr*dr+dg*dg+db*db > tol2 {
+			if miss--; miss < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// scoreAt get the similarity score, 1.0 is the exact match
+func scoreAt(pix []uint8, base int, offs []int, tp []tplPixel) float64 {
+	if len(offs) == 0 {
+		return 1
+	}
+
+	var sum float64
+	for i, off := range offs {
+		p := pix[base+off : base+off+3]
+		dr := float64(int32(p[0]) - tp[i].r)
+		dg := float64(int32(p[1]) - tp[i].g)
+		db := float64(int32(p[2]) - tp[i].b)
+		sum += math.Sqrt(dr*dr + dg*dg + db*db)
+	}
+	return 1 - sum/float64(len(offs))/maxColorDist
+}
+
+// pixColor get the Color of the RGBA pixel
+func pixColor(p []uint8) Color {
+	return Color{R: p[0], G: p[1], B: p[2]}
+}
+
+func (p tplPixel) color() Color {
+	return Color{R: uint8(p.r), G: uint8(p.g), B: uint8(p.b)}
+}
+
+// matchDist is the matchAt() of the template color distance
+func matchDist(pix []uint8, base int, offs []int, t template, tol float64) bool {
+	miss := t.miss
+	for i, off := range offs {
+		if t.dist(pixColor(pix[base+off:]), t.pix[i].color()) > tol {
+			if miss--; miss < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// scoreDist is the scoreAt() of the template color distance
+func scoreDist(pix []uint8, base int, offs []int, t template) float64 {
+	if len(offs) == 0 {
+		return 1
+	}
+
+	var sum float64
+	for i, off := range offs {
+		sum += math.Min(t.dist(pixColor(pix[base+off:]), t.pix[i].color()), 1)
+	}
+	return 1 - sum/float64(len(offs))
+}
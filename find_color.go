@@ -0,0 +1,273 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"sort"
+)
+
+// ScanOrder is the pixel scan order of the color search
+type ScanOrder int
+
+const (
+	// ScanRows scan the rows, left to right and top to bottom
+	ScanRows ScanOrder = iota
+	// ScanCols scan the columns, top to bottom and left to right
+	ScanCols
+	// ScanCenter scan from the region center to the edges
+	ScanCenter
+)
+
+// ColorOpts is the color search options, the zero value is the default
+type ColorOpts struct {
+	// Tolerance is the color tolerance (0.0 ~ 1.0),
+	// default is the DefaultTolerance, use a tiny value for the exact match
+	Tolerance float64
+	// Order is the scan order, default is the ScanRows
+	Order ScanOrder
+	// MinArea is the min pixels number of the FindColorBlobs, default is 1
+	MinArea int
+	// Distance is the color distance, default is the ColorDistance
+	Distance DistanceFunc
+}
+
+// colorScan is the color search of a region capture
+type colorScan struct {
+	src     *image.RGBA
+	o       Point
+	r, g, b int32
+	tol2    int32
+	order   ScanOrder
+	// dist is the color distance, nil is the rgb euclidean distance
+	dist  DistanceFunc
+	color Color
+	tol   float64
+}
+
+func colorOpt(opts []ColorOpts) ColorOpts {
+	var opt ColorOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Tolerance <= 0 {
+		opt.Tolerance = DefaultTolerance
+	}
+	if opt.MinArea <= 0 {
+		opt.MinArea = 1
+	}
+	return opt
+}
+
+func newColorScan(color CHex, src *image.RGBA, o Point, opt ColorOpts) colorScan {
+	hex := uint32(color)
+	tol := opt.Tolerance * maxColorDist
+	return colorScan{
+		src: src, o: o,
+		r: int32(hex >> 16 & 0xff), g: int32(hex >> 8 & 0xff), b: int32(hex & 0xff),
+		tol2: int32(tol * tol), order: opt.Order,
+		dist: distFunc(opt.Distance), color: HexColor(color), tol: opt.Tolerance,
+	}
+}
+
+func regionColorScan(color CHex, region interface{}, opts []ColorOpts) (colorScan, ColorOpts, error) {
+	opt := colorOpt(opts)
+	src, o, err := regionRGBA(region)
+	if err != nil {
+		return colorScan{}, opt, err
+	}
+	return newColorScan(color, src, o, opt), opt, nil
+}
+
+// first get the first matched pixel by the scan order
+func (c colorScan) first() (Point, bool) {
+	pt, found := Point{}, false
+	c.each(func(x, y int) bool {
+		pt, found = Point{X: x + c.o.X, Y: y + c.o.Y}, true
+		return false
+	})
+	return pt, found
+}
+
+// match check the pixel color is within the tolerance
+func (c colorScan) match(x, y int) bool {
+	p := c.src.Pix[y*c.src.Stride+x*4:]
+	if c.dist != nil {
+		return c.dist(pixColor(p), c.color) <= c.tol
+	}
+
+	dr := int32(p[0]) - c.r
+	dg := int32(p[1]) - c.g
+	db := int32(p[2]) - c.b
+	return dr*dr+dg*dg+db*db <= c.tol2
+}
+
+// each call the fn with the matched pixels in the scan order,
+// stop when the fn return false
+func (c colorScan) each(fn func(x, y int) bool) {
+	w, h := c.src.Rect.Dx(), c.src.Rect.Dy()
+	switch c.order {
+	case ScanCols:
+		for x := 0; x < w; x++ {
+			for y := 0; y < h; y++ {
+				if c.match(x, y) && !fn(x, y) {
+					return
+				}
+			}
+		}
+	case ScanCenter:
+		var arr []image.Point
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if c.match(x, y) {
+					arr = append(arr, image.Pt(x, y))
+				}
+			}
+		}
+
+		cx, cy := w/2, h/2
+		sort.SliceStable(arr, func(i, j int) bool {
+			return dist2(arr[i], cx, cy) < dist2(arr[j], cx, cy)
+		})
+		for _, p := range arr {
+			if !fn(p.X, p.Y) {
+				return
+			}
+		}
+	default:
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if c.match(x, y) && !fn(x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func dist2(p image.Point, x, y int) int {
+	return (p.X-x)*(p.X-x) + (p.Y-y)*(p.Y-y)
+}
+
+// FindColor find the first pixel of the color in the region by the scan order,
+// return the screen point or the ErrNotFound,
+// the region is the same as FindBitmap()
+//
+// robotgo.FindColor(color CHex, region interface{}, opts ColorOpts)
+//
+// Examples:
+//
+//	pt, err := robotgo.FindColor(0xff0000, robotgo.Rect{
+//		Point: robotgo.Point{X: 10, Y: 10}, Size: robotgo.Size{W: 200, H: 100}},
+//		robotgo.ColorOpts{Order: robotgo.ScanCenter})
+func FindColor(color CHex, region interface{}, opts ...ColorOpts) (Point, error) {
+	c, _, err := regionColorScan(color, region, opts)
+	if err != nil {
+		return Point{}, err
+	}
+
+	pt, found := c.first()
+	if !found {
+		return Point{}, ErrNotFound
+	}
+	return pt, nil
+}
+
+// FindEveryColor find all the pixels of the color in the region by the scan order
+func FindEveryColor(color CHex, region interface{}, opts ...ColorOpts) ([]Point, error) {
+	c, _, err := regionColorScan(color, region, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var arr []Point
+	c.each(func(x, y int) bool {
+		arr = append(arr, Point{X: x + c.o.X, Y: y + c.o.Y})
+		return true
+	})
+	return arr, nil
+}
+
+// CountColor count the pixels of the color in the region
+func CountColor(color CHex, region interface{}, opts ...ColorOpts) (int, error) {
+	c, _, err := regionColorScan(color, region, opts)
+	if err != nil {
+		return 0, err
+	}
+	// The order is not used
+	c.order = ScanRows
+
+	n := 0
+	c.each(func(x, y int) bool {
+		n++
+		return true
+	})
+	return n, nil
+}
+
+// FindColorBlobs find the bounding boxes of the connected (8-connected) color
+// blobs in the region, in the scan order of the blob first pixel,
+// the blobs smaller than the opts MinArea are dropped
+//
+// Examples:
+//
+//	leds, err := robotgo.FindColorBlobs(0x00ff00, nil, robotgo.ColorOpts{MinArea: 9})
+func FindColorBlobs(color CHex, region interface{}, opts ...ColorOpts) ([]Rect, error) {
+	c, opt, err := regionColorScan(color, region, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := c.src.Rect.Dx(), c.src.Rect.Dy()
+	seen := make([]bool, w*h)
+	var (
+		arr   []Rect
+		stack []image.Point
+	)
+
+	c.each(func(x, y int) bool {
+		if seen[y*w+x] {
+			return true
+		}
+
+		seen[y*w+x] = true
+		stack = append(stack[:0], image.Pt(x, y))
+		box, area := image.Rect(x, y, x+1, y+1), 0
+		for len(stack) > 0 {
+			p := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			area++
+			box = box.Union(image.Rect(p.X, p.Y, p.X+1, p.Y+1))
+
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := p.X+dx, p.Y+dy
+					if nx < 0 || ny < 0 || nx >= w || ny >= h ||
+						seen[ny*w+nx] || !c.match(nx, ny) {
+						continue
+					}
+					seen[ny*w+nx] = true
+					stack = append(stack, image.Pt(nx, ny))
+				}
+			}
+		}
+
+		if area >= opt.MinArea {
+			arr = append(arr, Rect{
+				Point{X: box.Min.X + c.o.X, Y: box.Min.Y + c.o.Y},
+				Size{W: box.Dx(), H: box.Dy()},
+			})
+		}
+		return true
+	})
+	return arr, nil
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+
+	"github.com/vcaesar/tt"
+	xdraw "golang.org/x/image/draw"
+)
+
+// testScreen make a screen like image, the flat background with some noise
+func testScreen(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < len(img.Pix); i += 4 {
+		v := uint8(0xe0 + r.Intn(8))
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = v, v, v, 0xff
+	}
+
+	for i := 0; i < 50; i++ {
+		c := color.RGBA{uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), 0xff}
+		x, y := r.Intn(w-100), r.Intn(h-40)
+		draw.Draw(img, image.Rect(x, y, x+60+r.Intn(40), y+20+r.Intn(20)),
+			image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func testButton(img *image.RGBA, x, y int) {
+	draw.Draw(img, image.Rect(x, y, x+40, y+20),
+		image.NewUniform(color.RGBA{0x20, 0x60, 0xc0, 0xff}), image.Point{}, draw.Src)
+	for i := 0; i < 10; i++ {
+		img.Set(x+15+i, y+10, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	}
+}
+
+func TestFindBitmap(t *testing.T) {
+	src := testScreen(400, 300)
+	testButton(src, 123, 77)
+	tpl := src.SubImage(image.Rect(123, 77, 163, 97))
+
+	m, err := FindBitmap(tpl, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 123, m.X)
+	tt.Equal(t, 77, m.Y)
+	tt.Equal(t, 40, m.W)
+	tt.Equal(t, 20, m.H)
+	tt.Equal(t, 1.0, m.Score)
+
+	// The theme changed a little
+	shift := testScreen(400, 300)
+	testButton(shift, 200, 150)
+	for i := 0; i < len(shift.Pix); i += 4 {
+		shift.Pix[i+2] -= 6
+	}
+	m, err = FindBitmap(tpl, shift)
+	tt.Nil(t, err)
+	tt.Equal(t, 200, m.X)
+	tt.Equal(t, 150, m.Y)
+	tt.True(t, m.Score < 1)
+
+	_, err = FindBitmap(tpl, shift, 0.001)
+	tt.Equal(t, ErrNotFound, err)
+
+	// The per call distance, only the red is compared
+	red := func(c1, c2 Color) float64 {
+		return float64(abs(int(c1.R)-int(c2.R))) / 255
+	}
+	m, err = FindBitmap(tpl, shift, FindOpts{Tolerance: 0.001, Distance: red})
+	tt.Nil(t, err)
+	tt.Equal(t, 200, m.X)
+	tt.Equal(t, 1.0, m.Score)
+
+	_, err = FindBitmap(tpl, shift, "0.1")
+	tt.NotNil(t, err)
+
+	_, err = FindBitmap(tpl, src.SubImage(image.Rect(0, 0, 30, 30)))
+	tt.Equal(t, ErrNotFound, err)
+
+	_, err = FindBitmap("tpl", src)
+	tt.NotNil(t, err)
+}
+
+func TestFindBitmapAlpha(t *testing.T) {
+	src := testScreen(300, 200)
+	testButton(src, 50, 60)
+
+	// The button icon on the other backgrounds
+	tpl := image.NewNRGBA(image.Rect(0, 0, 14, 5))
+	for i := 0; i < 10; i++ {
+		tpl.Set(2+i, 2, color.NRGBA{0xff, 0xff, 0xff, 0xff})
+	}
+	tpl.Set(0, 0, color.NRGBA{0, 0xff, 0, 0x10})
+
+	m, err := FindBitmap(tpl, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 63, m.X)
+	tt.Equal(t, 68, m.Y)
+
+	// All transparent, use the pixels
+	tpl = image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	_, err = FindBitmap(tpl, src)
+	tt.Equal(t, ErrNotFound, err)
+}
+
+func TestFindAllBitmaps(t *testing.T) {
+	src := testScreen(400, 300)
+	pts := []image.Point{{10, 10}, {10, 40}, {10, 70}, {300, 250}}
+	for _, p := range pts {
+		testButton(src, p.X, p.Y)
+	}
+	tpl := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(tpl, tpl.Bounds(), src, image.Pt(10, 10), draw.Src)
+
+	arr, err := FindAllBitmaps(tpl, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 4, len(arr))
+	for _, m := range arr {
+		tt.True(t, m.Point == Point{X: 10, Y: 10} || m.Point == Point{X: 10, Y: 40} ||
+			m.Point == Point{X: 10, Y: 70} || m.Point == Point{X: 300, Y: 250})
+		tt.Equal(t, 1.0, m.Scale)
+		tt.Equal(t, 1.0, m.Score)
+	}
+
+	arr, err = FindAllBitmaps(tpl, src, FindOpts{MaxResults: 2})
+	tt.Nil(t, err)
+	tt.Equal(t, 2, len(arr))
+	tt.Equal(t, 10, arr[0].Y)
+	tt.Equal(t, 40, arr[1].Y)
+
+	_, err = FindAllBitmaps(tpl, testScreen(400, 300))
+	tt.Equal(t, ErrNotFound, err)
+}
+
+func TestFindAllBitmapsScale(t *testing.T) {
+	btn := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	testButton(btn, 0, 0)
+
+	// The 1.25 HiDPI screen
+	src := testScreen(400, 300)
+	xdraw.BiLinear.Scale(src, image.Rect(100, 120, 150, 145), btn, btn.Rect, xdraw.Src, nil)
+
+	_, err := FindAllBitmaps(btn, src)
+	tt.Equal(t, ErrNotFound, err)
+
+	arr, err := FindAllBitmaps(btn, src, FindOpts{
+		MaxResults: 1, MinScale: 0.75, MaxScale: 1.5})
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, 1.25, arr[0].Scale)
+	tt.Equal(t, Rect{Point{X: 100, Y: 120}, Size{W: 50, H: 25}}, arr[0].Rect)
+}
+
+func TestSuppress(t *testing.T) {
+	a := Rect{Point{X: 0, Y: 0}, Size{W: 10, H: 10}}
+	b := Rect{Point{X: 5, Y: 0}, Size{W: 10, H: 10}}
+	c := Rect{Point{X: 1, Y: 0}, Size{W: 10, H: 10}}
+	tt.Equal(t, 1.0, iou(a, a))
+	tt.Equal(t, 50.0/150, iou(a, b))
+	tt.Equal(t, 0.0, iou(a, Rect{Point{X: 10, Y: 10}, Size{W: 5, H: 5}}))
+
+	arr := suppress([]Match{{Rect: b, Score: 0.9}, {Rect: a, Score: 0.95},
+		{Rect: c, Score: 0.99}}, 0.3, 0)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, c, arr[0].Rect)
+
+	arr = suppress([]Match{{Rect: b, Score: 0.9}, {Rect: a, Score: 0.95}}, 0.5, 0)
+	tt.Equal(t, 2, len(arr))
+	tt.Equal(t, a, arr[0].Rect)
+
+	// The scan keeps the local maximum only
+	set := localMax{overlap: 0.3}
+	for _, m := range []Match{{Rect: b, Score: 0.9}, {Rect: a, Score: 0.95},
+		{Rect: c, Score: 0.99}, {Rect: a, Score: 0.97}} {
+		set.add(m)
+	}
+	tt.Equal(t, 1, len(set.arr))
+	tt.Equal(t, c, set.arr[0].Rect)
+
+	far := Rect{Point{X: 50, Y: 50}, Size{W: 10, H: 10}}
+	set.add(Match{Rect: far, Score: 0.5})
+	tt.Equal(t, 2, len(set.arr))
+
+	opt := FindOpts{MinScale: 0.8, MaxScale: 1.2, ScaleStep: 0.1}.withDefault()
+	tt.Equal(t, 5, len(opt.scales()))
+	tt.Equal(t, 1, len(FindOpts{}.withDefault().scales()))
+}
+
+func TestSpread(t *testing.T) {
+	// The 7919 * 89 skips the 7919, the 7921 (89 * 89) is not coprime
+	for _, n := range []int{0, 1, 2, 7919, 7919 * 2, 7919 * 89, 10000} {
+		pix := make([]tplPixel, n)
+		for i := range pix {
+			pix[i].x = i
+		}
+
+		seen := make(map[int]bool)
+		for _, p := range spread(pix) {
+			seen[p.x] = true
+		}
+		tt.Equal(t, n, len(seen))
+	}
+}
+
+func BenchmarkFindBitmap(b *testing.B) {
+	src := testScreen(1920, 1080)
+	testButton(src, 1500, 900)
+	tpl := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(tpl, tpl.Bounds(), src, image.Pt(1500, 900), draw.Src)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindBitmap(tpl, src)
+	}
+}
+
+func TestFindColor(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 100, 80))
+	draw.Draw(src, src.Rect, image.NewUniform(color.RGBA{0xe0, 0xe0, 0xe0, 0xff}),
+		image.Point{}, draw.Src)
+	led := image.NewUniform(color.RGBA{0x00, 0xc8, 0x10, 0xff})
+	draw.Draw(src, image.Rect(80, 5, 84, 9), led, image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(10, 40, 13, 43), led, image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(48, 38, 50, 40), led, image.Point{}, draw.Src)
+	src.Set(60, 70, color.RGBA{0x00, 0xcc, 0x10, 0xff})
+
+	pt, err := FindColor(0x00c810, src)
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 80, Y: 5}, pt)
+
+	pt, err = FindColor(0x00c810, src, ColorOpts{Order: ScanCols})
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 10, Y: 40}, pt)
+
+	pt, err = FindColor(0x00c810, src, ColorOpts{Order: ScanCenter})
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 49, Y: 39}, pt)
+
+	_, err = FindColor(0xff0000, src)
+	tt.Equal(t, ErrNotFound, err)
+
+	arr, err := FindEveryColor(0x00c810, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 30, len(arr))
+	tt.Equal(t, Point{X: 80, Y: 5}, arr[0])
+
+	n, err := CountColor(0x00c810, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 30, n)
+	n, _ = CountColor(0x00c810, src, ColorOpts{Tolerance: 0.001})
+	tt.Equal(t, 29, n)
+
+	boxes, err := FindColorBlobs(0x00c810, src)
+	tt.Nil(t, err)
+	tt.Equal(t, 4, len(boxes))
+	tt.Equal(t, Rect{Point{X: 80, Y: 5}, Size{W: 4, H: 4}}, boxes[0])
+	tt.Equal(t, Rect{Point{X: 48, Y: 38}, Size{W: 2, H: 2}}, boxes[1])
+	tt.Equal(t, Rect{Point{X: 10, Y: 40}, Size{W: 3, H: 3}}, boxes[2])
+
+	boxes, _ = FindColorBlobs(0x00c810, src, ColorOpts{MinArea: 9})
+	tt.Equal(t, 2, len(boxes))
+}
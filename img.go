@@ -13,7 +13,6 @@ package robotgo
 
 import (
 	"image"
-	"os/exec"
 	"unsafe"
 
 	"github.com/vcaesar/imgo"
@@ -134,49 +133,26 @@ func ToUint8p(dst []uint8) *uint8 {
 	return (*uint8)(ptr)
 }
 
-// ToRGBAGo convert Bitmap to standard image.RGBA
+// ToRGBAGo convert Bitmap to standard image.RGBA,
+// the bitmap is the 24 or 32 bits BGR(A), the 24 bits alpha is opaque
 func ToRGBAGo(bmp1 Bitmap) *image.RGBA {
 	img1 := image.NewRGBA(image.Rect(0, 0, bmp1.Width, bmp1.Height))
-	img1.Pix = make([]uint8, bmp1.Bytewidth*bmp1.Height)
-
-	copyToVUint8A(img1.Pix, bmp1.ImgBuf)
-	img1.Stride = bmp1.Bytewidth
-	return img1
-}
-
-func val(p *uint8, n int) uint8 {
-	addr := uintptr(unsafe.Pointer(p))
-	addr += uintptr(n)
-	p1 := (*uint8)(unsafe.Pointer(addr))
-	return *p1
-}
-
-func copyToVUint8A(dst []uint8, src *uint8) {
-	for i := 0; i <= len(dst)-4; i += 4 {
-		dst[i] = val(src, i+2)
-		dst[i+1] = val(src, i+1)
-		dst[i+2] = val(src, i)
-		dst[i+3] = val(src, i+3)
+	if bmp1.ImgBuf == nil || bmp1.BytesPerPixel < 3 {
+		return img1
 	}
-}
 
-// GetText get the image text by tesseract ocr
-//
-// robotgo.GetText(imgPath, lang string)
-func GetText(imgPath string, args ...string) (string, error) {
-	var lang = "eng"
-
-	if len(args) > 0 {
-		lang = args[0]
-		if lang == "zh" {
-			lang = "chi_sim"
+	bpp := int(bmp1.BytesPerPixel)
+	src := unsafe.Slice(bmp1.ImgBuf, bmp1.Bytewidth*bmp1.Height)
+	for y := 0; y < bmp1.Height; y++ {
+		s := src[y*bmp1.Bytewidth:]
+		d := img1.Pix[y*img1.Stride:]
+		for x := 0; x < bmp1.Width; x++ {
+			p := s[x*bpp:]
+			d[x*4], d[x*4+1], d[x*4+2], d[x*4+3] = p[2], p[1], p[0], 0xff
+			if bpp == 4 {
+				d[x*4+3] = p[3]
+			}
 		}
 	}
-
-	body, err := exec.Command("tesseract", imgPath,
-		"stdout", "-l", lang).Output()
-	if err != nil {
-		return "", err
-	}
-	return string(body), nil
+	return img1
 }
@@ -0,0 +1,41 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestToRGBAGo(t *testing.T) {
+	for _, bpp := range []int{3, 4} {
+		bit, buf := testBitmap(5, 3, bpp)
+		p := buf[2*bit.Bytewidth+4*bpp:]
+		p[0], p[1], p[2] = 0x30, 0x20, 0x10
+		if bpp == 4 {
+			p[3] = 0x80
+		}
+
+		img := ToRGBAGo(bit)
+		tt.Equal(t, 5, img.Bounds().Dx())
+		tt.Equal(t, 20, img.Stride)
+		a := uint8(0xff)
+		if bpp == 4 {
+			a = 0x80
+		}
+		tt.Equal(t, color.RGBA{0x10, 0x20, 0x30, a}, img.RGBAAt(4, 2))
+		tt.Equal(t, uint8(0x80), img.RGBAAt(0, 0).R)
+		tt.Equal(t, 5*3*4, len(img.Pix))
+	}
+}
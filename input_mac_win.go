@@ -0,0 +1,70 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build darwin || windows
+// +build darwin windows
+
+package robotgo
+
+import "errors"
+
+// BlockInput block the user mouse and keyboard input (X11)
+func BlockInput(b bool, timeout ...int) error {
+	return errors.New("BlockInput is only supported on Linux")
+}
+
+// IsBlocked return true if the user input is blocked by BlockInput
+func IsBlocked() bool {
+	return false
+}
+
+func passInput(fn func()) {
+	fn()
+}
+
+// ConfinePointer confine the mouse pointer to the rect (X11)
+func ConfinePointer(r Rect) error {
+	return errors.New("ConfinePointer is only supported on Linux")
+}
+
+// ConfineWindow confine the mouse pointer to the window bounds (X11)
+func ConfineWindow(pid int, args ...int) error {
+	return errors.New("ConfineWindow is only supported on Linux")
+}
+
+// ReleasePointer release the pointer confined by the ConfinePointer
+func ReleasePointer() error {
+	return nil
+}
+
+func confineClamp(x, y int) (int, int) {
+	return x, y
+}
+
+// GetPointerControl get the X server pointer acceleration settings
+func GetPointerControl() (PointerControl, error) {
+	return PointerControl{}, errors.New("GetPointerControl is only supported on Linux")
+}
+
+// SetPointerControl set the X server pointer acceleration settings
+func SetPointerControl(pc PointerControl) error {
+	return errors.New("SetPointerControl is only supported on Linux")
+}
+
+// IsRelativeAccel return true if the system pointer acceleration
+// will scale the relative motion (dx, dy) of the MoveDelta() (Windows)
+func IsRelativeAccel(dx, dy int) bool {
+	return (dx != 0 || dy != 0) && sysRelativeAccel()
+}
+
+func xDoubleClickTime() int {
+	return defaultDoubleClickTime
+}
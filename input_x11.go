@@ -0,0 +1,703 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"encoding/binary"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/xfixes"
+	"github.com/robotn/xgb/xproto"
+	"github.com/robotn/xgbutil"
+	"github.com/robotn/xgbutil/keybind"
+)
+
+// inputBlock hold the BlockInput grab connection and state,
+// the grabs live on their own connection, so closing it always releases them
+type inputBlock struct {
+	sync.Mutex
+
+	xu    *xgbutil.XUtil
+	root  xproto.Window
+	timer *time.Timer
+
+	// the pointer position robotgo left, restored if the user moved it
+	x, y int16
+}
+
+var block inputBlock
+
+// the modifiers checked by the unlock hotkey, ignore the lock keys
+const hotkeyMods = xproto.ModMaskShift | xproto.ModMaskControl |
+	xproto.ModMask1 | xproto.ModMask4
+
+// BlockInput block the user mouse and keyboard input (X11),
+// the robotgo mouse and keyboard events are still delivered.
+// It use the active pointer and keyboard grabs on the root window,
+// press the BlockHotkey or wait BlockTimeout millisecond to release it.
+//
+// robotgo.BlockInput(block bool, timeout int)
+//
+// Examples:
+//
+//	robotgo.BlockInput(true)
+//	defer robotgo.BlockInput(false)
+//
+//	robotgo.BlockInput(true, 10000) // auto release after 10 second
+func BlockInput(b bool, timeout ...int) error {
+	block.Lock()
+	defer block.Unlock()
+
+	if !b {
+		block.release()
+		return nil
+	}
+
+	tm := BlockTimeout
+	if len(timeout) > 0 {
+		tm = timeout[0]
+	}
+
+	if block.xu == nil {
+		if err := block.open(); err != nil {
+			return err
+		}
+	}
+
+	if block.timer != nil {
+		block.timer.Stop()
+		block.timer = nil
+	}
+	if tm > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(time.Duration(tm)*time.Millisecond, func() {
+			block.Lock()
+			defer block.Unlock()
+
+			// the fired timer of a released or renewed block
+			if block.timer != timer {
+				return
+			}
+			log.Println("BlockInput timeout, release the input.")
+			block.release()
+		})
+		block.timer = timer
+	}
+	return nil
+}
+
+// IsBlocked return true if the user input is blocked by BlockInput
+func IsBlocked() bool {
+	block.Lock()
+	defer block.Unlock()
+
+	return block.xu != nil
+}
+
+func (b *inputBlock) open() error {
+	xu, err := xgbutil.NewConn()
+	if err != nil {
+		return err
+	}
+	keybind.Initialize(xu)
+
+	mods, codes, err := keybind.ParseString(xu, xHotkey(BlockHotkey))
+	if err != nil {
+		xu.Conn().Close()
+		return err
+	}
+
+	b.xu, b.root = xu, xu.RootWin()
+	if err := b.grab(); err != nil {
+		xu.Conn().Close()
+		b.xu = nil
+		return err
+	}
+
+	go b.wait(xu, mods, codes)
+	return nil
+}
+
+// release ungrab and close the grab connection, need hold the lock
+func (b *inputBlock) release() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if b.xu == nil {
+		return
+	}
+
+	b.ungrab()
+	b.xu.Conn().Close()
+	b.xu = nil
+}
+
+func (b *inputBlock) grab() error {
+	c := b.xu.Conn()
+	pr, err := xproto.GrabPointer(c, false, b.root, 0,
+		xproto.GrabModeAsync, xproto.GrabModeAsync,
+		xproto.WindowNone, xproto.CursorNone, xproto.TimeCurrentTime).Reply()
+	if err != nil {
+		return err
+	}
+	if pr.Status != xproto.GrabStatusSuccess {
+		return grabErr("pointer", pr.Status)
+	}
+
+	kr, err := xproto.GrabKeyboard(c, false, b.root, xproto.TimeCurrentTime,
+		xproto.GrabModeAsync, xproto.GrabModeAsync).Reply()
+	if err != nil {
+		xproto.UngrabPointer(c, xproto.TimeCurrentTime)
+		return err
+	}
+	if kr.Status != xproto.GrabStatusSuccess {
+		xproto.UngrabPointer(c, xproto.TimeCurrentTime)
+		return grabErr("keyboard", kr.Status)
+	}
+
+	if qr, err := xproto.QueryPointer(c, b.root).Reply(); err == nil {
+		b.x, b.y = qr.RootX, qr.RootY
+	}
+	return nil
+}
+
+func (b *inputBlock) ungrab() {
+	c := b.xu.Conn()
+	xproto.UngrabKeyboard(c, xproto.TimeCurrentTime)
+	// Checked for a round trip, the ungrab must land before the XTest events
+	xproto.UngrabPointerChecked(c, xproto.TimeCurrentTime).Check()
+}
+
+// restore move the pointer back if the user moved it during the block
+func (b *inputBlock) restore() {
+	c := b.xu.Conn()
+	qr, err := xproto.QueryPointer(c, b.root).Reply()
+	if err != nil || (qr.RootX == b.x && qr.RootY == b.y) {
+		return
+	}
+
+	xproto.WarpPointerChecked(c, xproto.WindowNone, b.root,
+		0, 0, 0, 0, b.x, b.y).Check()
+}
+
+// wait read the grabbed keyboard events and watch for the unlock hotkey
+func (b *inputBlock) wait(xu *xgbutil.XUtil, mods uint16, codes []xproto.Keycode) {
+	for {
+		ev, err := xu.Conn().WaitForEvent()
+		if ev == nil && err == nil {
+			// the connection is closed
+			return
+		}
+
+		kp, ok := ev.(xproto.KeyPressEvent)
+		if !ok || kp.State&hotkeyMods != mods&hotkeyMods {
+			continue
+		}
+
+		for _, code := range codes {
+			if kp.Detail == code {
+				log.Println("BlockInput hotkey pressed, release the input.")
+				BlockInput(false)
+				return
+			}
+		}
+	}
+}
+
+// passInput inject the single input event by fn, the grabs are released
+// only around the event, so the XTest event reach the windows rather than
+// the grab and the user input stay blocked between the events.
+// fn must not sleep, the callers sleep between the passInput calls
+func passInput(fn func()) {
+	block.Lock()
+	if block.xu == nil {
+		block.Unlock()
+		fn()
+		return
+	}
+	defer block.Unlock()
+
+	block.restore()
+	block.ungrab()
+	fn()
+
+	if err := block.grab(); err != nil {
+		log.Println("BlockInput grab again errors is: ", err)
+		block.release()
+	}
+}
+
+func grabErr(dev string, status byte) error {
+	m := map[byte]string{
+		xproto.GrabStatusAlreadyGrabbed: "already grabbed",
+		xproto.GrabStatusInvalidTime:    "invalid time",
+		xproto.GrabStatusNotViewable:    "not viewable",
+		xproto.GrabStatusFrozen:         "frozen",
+	}
+
+	return errors.New("BlockInput grab the " + dev + " failed: " + m[status])
+}
+
+// xHotkey convert the robotgo key and modifiers to the xgbutil key string
+func xHotkey(keys []string) string {
+	if len(keys) <= 0 {
+		return ""
+	}
+
+	mods := map[string]string{
+		"ctrl": "control", "lctrl": "control", "rctrl": "control",
+		"alt": "mod1", "lalt": "mod1", "ralt": "mod1",
+		"cmd": "mod4", "lcmd": "mod4", "rcmd": "mod4",
+		"shift": "shift", "lshift": "shift", "rshift": "shift",
+	}
+	names := map[string]string{
+		"esc": "Escape", "escape": "Escape", "enter": "Return",
+		"space": "space", "tab": "Tab", "backspace": "BackSpace",
+		"delete": "Delete", "pause": "Pause", "insert": "Insert",
+		"home": "Home", "end": "End",
+	}
+
+	key := keys[0]
+	if v, ok := names[key]; ok {
+		key = v
+	}
+
+	var arr []string
+	for _, m := range keys[1:] {
+		if v, ok := mods[m]; ok {
+			arr = append(arr, v)
+		}
+	}
+
+	return strings.Join(append(arr, key), "-")
+}
+
+// pointerConfine hold the ConfinePointer region and the XFixes barriers
+type pointerConfine struct {
+	sync.Mutex
+
+	// rect is the screen pixels rect, the same as the MoveScale() result
+	rect     *Rect
+	barriers []xfixes.Barrier
+}
+
+var confine pointerConfine
+
+// ConfinePointer confine the mouse pointer to the rect (X11),
+// it use the XFixes pointer barriers for the user mouse,
+// and the robotgo Move() and MoveSmooth() target will be clamped to the rect
+//
+// Examples:
+//
+//	robotgo.ConfinePointer(robotgo.Rect{
+//		Point: robotgo.Point{X: 100, Y: 100},
+//		Size:  robotgo.Size{W: 800, H: 600},
+//	})
+//	defer robotgo.ReleasePointer()
+func ConfinePointer(r Rect) error {
+	if r.W <= 0 || r.H <= 0 {
+		return errors.New("ConfinePointer the rect size must be greater than 0")
+	}
+
+	c, err := xfixesConn()
+	if err != nil {
+		return err
+	}
+	root := xproto.Setup(c).DefaultScreen(c).Root
+
+	x1, y1 := MoveScale(r.X, r.Y)
+	x2, y2 := MoveScale(r.X+r.W, r.Y+r.H)
+	box := Rect{Point{X: x1, Y: y1}, Size{W: max(x2-x1, 1), H: max(y2-y1, 1)}}
+
+	confine.Lock()
+	defer confine.Unlock()
+
+	// Move the pointer into the rect, or the barriers will keep it outside,
+	// the warp is not blocked by the old barriers
+	if qr, err := xproto.QueryPointer(c, root).Reply(); err == nil {
+		x, y := int(qr.RootX), int(qr.RootY)
+		if cx, cy := clampRect(box, x, y); cx != x || cy != y {
+			xproto.WarpPointerChecked(c, xproto.WindowNone, root,
+				0, 0, 0, 0, int16(cx), int16(cy)).Check()
+		}
+	}
+
+	// Replace the barriers in the one lock, keep the old if it's failed
+	barriers, err := createBarriers(c, root, box)
+	if err != nil {
+		return err
+	}
+
+	confine.remove(c)
+	confine.rect, confine.barriers = &box, barriers
+	return nil
+}
+
+// createBarriers create the barriers of the rect edges
+func createBarriers(c *xgb.Conn, root xproto.Window, r Rect) ([]xfixes.Barrier, error) {
+	var arr []xfixes.Barrier
+	for _, e := range barrierEdges(r) {
+		id, err := xfixes.NewBarrierId(c)
+		if err == nil {
+			err = xfixes.CreatePointerBarrierChecked(c, id, root,
+				uint16(e[0]), uint16(e[1]), uint16(e[2]), uint16(e[3]),
+				uint32(e[4]), 0, nil).Check()
+		}
+
+		if err != nil {
+			for _, id := range arr {
+				xfixes.DeletePointerBarrier(c, id)
+			}
+			return nil, err
+		}
+		arr = append(arr, id)
+	}
+	return arr, nil
+}
+
+// barrierEdges get the (x1, y1, x2, y2, directions) of the rect edges,
+// the barriers block the motion out of the rect
+func barrierEdges(r Rect) [][5]int {
+	x1, y1, x2, y2 := r.X, r.Y, r.X+r.W, r.Y+r.H
+	return [][5]int{
+		{x1, y1, x1, y2, xfixes.BarrierDirectionsPositiveX},
+		{x2, y1, x2, y2, xfixes.BarrierDirectionsNegativeX},
+		{x1, y1, x2, y1, xfixes.BarrierDirectionsPositiveY},
+		{x1, y2, x2, y2, xfixes.BarrierDirectionsNegativeY},
+	}
+}
+
+// ConfineWindow confine the mouse pointer to the window bounds (X11),
+// the args same with the GetBounds()
+//
+// Examples:
+//
+//	robotgo.ConfineWindow(pid)
+//	defer robotgo.ReleasePointer()
+func ConfineWindow(pid int, args ...int) error {
+	x, y, w, h := GetBounds(pid, args...)
+	if w <= 0 || h <= 0 {
+		return errors.New("ConfineWindow get the window bounds failed")
+	}
+
+	return ConfinePointer(Rect{Point{X: x, Y: y}, Size{W: w, H: h}})
+}
+
+// ReleasePointer release the pointer confined by the ConfinePointer
+func ReleasePointer() error {
+	c, err := xfixesConn()
+	if err != nil {
+		return err
+	}
+
+	confine.Lock()
+	defer confine.Unlock()
+
+	confine.remove(c)
+	return nil
+}
+
+// remove delete the barriers, need hold the lock
+func (p *pointerConfine) remove(c *xgb.Conn) {
+	for _, id := range p.barriers {
+		xfixes.DeletePointerBarrier(c, id)
+	}
+	p.barriers = nil
+	p.rect = nil
+}
+
+// confineClamp clamp the screen pixels (x, y) to the ConfinePointer rect,
+// the (x, y) is the MoveScale() result
+func confineClamp(x, y int) (int, int) {
+	confine.Lock()
+	defer confine.Unlock()
+
+	if confine.rect == nil {
+		return x, y
+	}
+	return clampRect(*confine.rect, x, y)
+}
+
+func clampRect(r Rect, x, y int) (int, int) {
+	if x < r.X {
+		x = r.X
+	}
+	if x > r.X+r.W-1 {
+		x = r.X + r.W - 1
+	}
+
+	if y < r.Y {
+		y = r.Y
+	}
+	if y > r.Y+r.H-1 {
+		y = r.Y + r.H - 1
+	}
+	return x, y
+}
+
+// GetPointerControl get the X server pointer acceleration settings
+func GetPointerControl() (PointerControl, error) {
+	c, err := getXConn()
+	if err != nil {
+		return PointerControl{}, err
+	}
+
+	r, err := xproto.GetPointerControl(c).Reply()
+	if err != nil {
+		return PointerControl{}, err
+	}
+
+	return PointerControl{
+		AccelNum:   int(r.AccelerationNumerator),
+		AccelDenom: int(r.AccelerationDenominator),
+		Threshold:  int(r.Threshold),
+	}, nil
+}
+
+// SetPointerControl set the X server pointer acceleration settings,
+// the value less than 0 will be restored to the server default
+//
+// Examples:
+//
+//	// disable the pointer acceleration
+//	robotgo.SetPointerControl(robotgo.PointerControl{1, 1, 0})
+func SetPointerControl(pc PointerControl) error {
+	num, den, threshold := pc.AccelNum, pc.AccelDenom, pc.Threshold
+	if num < 0 || den < 0 {
+		num, den = -1, -1
+	}
+	if threshold < 0 {
+		threshold = -1
+	}
+	// The X server returns the BadValue
+	if den == 0 {
+		return errors.New("SetPointerControl the AccelDenom must not be 0")
+	}
+
+	c, err := getXConn()
+	if err != nil {
+		return err
+	}
+
+	return xproto.ChangePointerControlChecked(c, int16(num), int16(den),
+		int16(threshold), true, true).Check()
+}
+
+// IsRelativeAccel return true if the X server pointer acceleration
+// will scale the relative motion (dx, dy) of the MoveDelta()
+func IsRelativeAccel(dx, dy int) bool {
+	pc, err := GetPointerControl()
+	if err != nil {
+		return false
+	}
+	return relativeAccel(pc, dx, dy)
+}
+
+// relativeAccel return true if the (dx, dy) is accelerated by the pc,
+// the X server accelerates the motion reach the threshold
+func relativeAccel(pc PointerControl, dx, dy int) bool {
+	if pc.AccelDenom == 0 || pc.AccelNum == pc.AccelDenom {
+		return false
+	}
+
+	return pc.Threshold == 0 || abs(dx)+abs(dy) >= pc.Threshold
+}
+
+// the double click time cache, it's read once from the X server
+var dcTime struct {
+	sync.Mutex
+	ms int
+}
+
+// xDoubleClickTime get the cached double click time of the X server
+func xDoubleClickTime() int {
+	dcTime.Lock()
+	defer dcTime.Unlock()
+
+	if dcTime.ms == 0 {
+		c, err := getXConn()
+		if err != nil {
+			return defaultDoubleClickTime
+		}
+		dcTime.ms = readDoubleClickTime(c)
+	}
+	return dcTime.ms
+}
+
+// readDoubleClickTime read the double click time from the XSETTINGS
+// "Net/DoubleClickTime" or the X resources "multiClickTime"
+func readDoubleClickTime(c *xgb.Conn) int {
+	data := xSettings(c)
+	if tm, ok := parseXSettingsInt(data, "Net/DoubleClickTime"); ok && tm > 0 {
+		return tm
+	}
+
+	if tm, ok := parseXResourceInt(xResources(c), "multiClickTime"); ok && tm > 0 {
+		return tm
+	}
+
+	return defaultDoubleClickTime
+}
+
+// xSettings get the XSETTINGS data of the settings manager,
+// return nil if it's not running
+func xSettings(c *xgb.Conn) []byte {
+	sel, err := xAtom(c, "_XSETTINGS_S"+strconv.Itoa(xScreenNum(c)))
+	if err != nil {
+		return nil
+	}
+
+	r, err := xproto.GetSelectionOwner(c, sel).Reply()
+	if err != nil || r.Owner == xproto.WindowNone {
+		return nil
+	}
+	return xProperty(c, r.Owner, "_XSETTINGS_SETTINGS")
+}
+
+// xResources get the X resources of the RESOURCE_MANAGER
+func xResources(c *xgb.Conn) string {
+	screen := xproto.Setup(c).DefaultScreen(c)
+	return string(xProperty(c, screen.Root, "RESOURCE_MANAGER"))
+}
+
+func xScreenNum(c *xgb.Conn) int {
+	setup := xproto.Setup(c)
+	for i, screen := range setup.Roots {
+		if screen.Root == setup.DefaultScreen(c).Root {
+			return i
+		}
+	}
+	return 0
+}
+
+func xAtom(c *xgb.Conn, name string) (xproto.Atom, error) {
+	r, err := xproto.InternAtom(c, true, uint16(len(name)), name).Reply()
+	if err != nil {
+		return 0, err
+	}
+	if r.Atom == xproto.AtomNone {
+		return 0, errors.New("the atom " + name + " not found")
+	}
+	return r.Atom, nil
+}
+
+// xProperty get the window property value, return nil if it's not set
+func xProperty(c *xgb.Conn, win xproto.Window, name string) []byte {
+	atom, err := xAtom(c, name)
+	if err != nil {
+		return nil
+	}
+
+	r, err := xproto.GetProperty(c, false, win, atom,
+		xproto.GetPropertyTypeAny, 0, 1<<20).Reply()
+	if err != nil {
+		return nil
+	}
+	return r.Value
+}
+
+// parseXSettingsInt find the integer setting from the XSETTINGS data,
+// see https://specifications.freedesktop.org/xsettings-spec/
+func parseXSettingsInt(data []byte, name string) (int, bool) {
+	if len(data) < 12 {
+		return 0, false
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == 1 {
+		order = binary.BigEndian
+	}
+
+	n := int(order.Uint32(data[8:]))
+	pos := 12
+	for i := 0; i < n; i++ {
+		if pos+4 > len(data) {
+			return 0, false
+		}
+
+		typ := data[pos]
+		nameLen := int(order.Uint16(data[pos+2:]))
+		pos += 4
+		if pos+pad4(nameLen)+4 > len(data) {
+			return 0, false
+		}
+
+		key := string(data[pos : pos+nameLen])
+		// skip the name and the last change serial
+		pos += pad4(nameLen) + 4
+
+		switch typ {
+		case 0: // integer
+			if pos+4 > len(data) {
+				return 0, false
+			}
+			if key == name {
+				return int(int32(order.Uint32(data[pos:]))), true
+			}
+			pos += 4
+		case 1: // string
+			if pos+4 > len(data) {
+				return 0, false
+			}
+			pos += 4 + pad4(int(order.Uint32(data[pos:])))
+		case 2: // color
+			pos += 8
+		default:
+			return 0, false
+		}
+	}
+
+	return 0, false
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// parseXResource find the resource value like "*multiClickTime: 400"
+func parseXResource(res, name string) (string, bool) {
+	for _, line := range strings.Split(res, "\n") {
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(line[:i])
+		if key == name || strings.HasSuffix(key, "*"+name) ||
+			strings.HasSuffix(key, "."+name) {
+			return strings.TrimSpace(line[i+1:]), true
+		}
+	}
+
+	return "", false
+}
+
+// parseXResourceInt find the integer resource
+func parseXResourceInt(res, name string) (int, bool) {
+	v, ok := parseXResource(res, name)
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/robotn/xgb/xfixes"
+	"github.com/vcaesar/tt"
+)
+
+func xsetting(b []byte, typ byte, name string, val []byte) []byte {
+	b = append(b, typ, 0)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(name)))
+	b = append(b, name...)
+	b = append(b, make([]byte, pad4(len(name))-len(name))...)
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	return append(b, val...)
+}
+
+func TestParseXSettings(t *testing.T) {
+	data := []byte{0, 0, 0, 0}
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, 3)
+
+	str := binary.LittleEndian.AppendUint32(nil, 5)
+	str = append(str, "Adwai\x00\x00\x00"...)
+	data = xsetting(data, 1, "Net/ThemeName", str)
+	data = xsetting(data, 2, "Gtk/Color", make([]byte, 8))
+	data = xsetting(data, 0, "Net/DoubleClickTime",
+		binary.LittleEndian.AppendUint32(nil, 250))
+
+	tm, ok := parseXSettingsInt(data, "Net/DoubleClickTime")
+	tt.True(t, ok)
+	tt.Equal(t, 250, tm)
+
+	_, ok = parseXSettingsInt(data, "Net/CursorBlinkTime")
+	tt.False(t, ok)
+	_, ok = parseXSettingsInt(data[:20], "Net/DoubleClickTime")
+	tt.False(t, ok)
+}
+
+func TestParseXResource(t *testing.T) {
+	res := "Xft.dpi:\t96\nXTerm*multiClickTime:\t300\n"
+	tm, ok := parseXResourceInt(res, "multiClickTime")
+	tt.True(t, ok)
+	tt.Equal(t, 300, tm)
+
+	_, ok = parseXResourceInt("Xft.dpi:\t96", "multiClickTime")
+	tt.False(t, ok)
+}
+
+func TestClampRect(t *testing.T) {
+	r := Rect{Point{X: 100, Y: 50}, Size{W: 200, H: 100}}
+
+	x, y := clampRect(r, 150, 80)
+	tt.Equal(t, 150, x)
+	tt.Equal(t, 80, y)
+
+	x, y = clampRect(r, 10, 500)
+	tt.Equal(t, 100, x)
+	tt.Equal(t, 149, y)
+
+	x, y = clampRect(r, 300, -10)
+	tt.Equal(t, 299, x)
+	tt.Equal(t, 50, y)
+}
+
+func TestConfineClamp(t *testing.T) {
+	x, y := confineClamp(-5, 5000)
+	tt.Equal(t, -5, x)
+	tt.Equal(t, 5000, y)
+
+	// The rect is the screen pixels, the second display is at the x 1920
+	confine.Lock()
+	confine.rect = &Rect{Point{X: 1920, Y: 0}, Size{W: 1280, H: 1024}}
+	confine.Unlock()
+	defer func() {
+		confine.Lock()
+		confine.rect = nil
+		confine.Unlock()
+	}()
+
+	x, y = confineClamp(10, 10)
+	tt.Equal(t, 1920, x)
+	tt.Equal(t, 10, y)
+	x, y = confineClamp(4000, 2000)
+	tt.Equal(t, 3199, x)
+	tt.Equal(t, 1023, y)
+}
+
+func TestBarrierEdges(t *testing.T) {
+	arr := barrierEdges(Rect{Point{X: 10, Y: 20}, Size{W: 30, H: 40}})
+	tt.Equal(t, [5]int{10, 20, 10, 60, xfixes.BarrierDirectionsPositiveX}, arr[0])
+	tt.Equal(t, [5]int{40, 20, 40, 60, xfixes.BarrierDirectionsNegativeX}, arr[1])
+	tt.Equal(t, [5]int{10, 20, 40, 20, xfixes.BarrierDirectionsPositiveY}, arr[2])
+	tt.Equal(t, [5]int{10, 60, 40, 60, xfixes.BarrierDirectionsNegativeY}, arr[3])
+}
+
+func TestRelativeAccel(t *testing.T) {
+	pc := PointerControl{AccelNum: 2, AccelDenom: 1, Threshold: 4}
+	tt.False(t, relativeAccel(pc, 2, -1))
+	tt.True(t, relativeAccel(pc, 2, -2))
+	tt.True(t, relativeAccel(pc, 0, 10))
+
+	tt.True(t, relativeAccel(PointerControl{AccelNum: 2, AccelDenom: 1}, 1, 0))
+	tt.False(t, relativeAccel(PointerControl{AccelNum: 1, AccelDenom: 1, Threshold: 4}, 9, 9))
+	tt.False(t, relativeAccel(PointerControl{AccelNum: 2, Threshold: 4}, 9, 9))
+}
+
+func TestStepsUnder(t *testing.T) {
+	tt.Equal(t, 1, stepsUnder(0, 0, 10))
+	tt.Equal(t, 1, stepsUnder(4, 6, 10))
+	tt.Equal(t, 10, stepsUnder(100, 0, 10))
+
+	// The steps of the MoveDeltaSmooth never reach the threshold
+	pc := PointerControl{AccelNum: 2, AccelDenom: 1, Threshold: 4}
+	for _, d := range [][2]int{{7, 5}, {-13, 11}, {100, -1}, {3, 3}, {1, -50}, {29, 31}} {
+		n := stepsUnder(abs(d[0]), abs(d[1]), pc.Threshold-1)
+		var px, py int
+		for i := 1; i <= n; i++ {
+			x, y := d[0]*i/n, d[1]*i/n
+			tt.False(t, relativeAccel(pc, x-px, y-py))
+			px, py = x, y
+		}
+		tt.Equal(t, d[0], px)
+		tt.Equal(t, d[1], py)
+	}
+
+	// The threshold 2, the (dx + dy) steps at least
+	tt.Equal(t, 8, stepsUnder(5, 3, 1))
+}
+
+func TestSetPointerControlDenom(t *testing.T) {
+	err := SetPointerControl(PointerControl{AccelNum: 2, Threshold: 4})
+	tt.NotNil(t, err)
+	tt.Equal(t, "SetPointerControl the AccelDenom must not be 0", err.Error())
+}
@@ -333,9 +333,13 @@ func CmdCtrl() string {
 
 // It sends a key press and release to the active application
 func tapKeyCode(code C.MMKeyCode, flags C.MMKeyFlags, pid C.uintptr) {
-	C.toggleKeyCode(code, true, flags, pid)
+	passInput(func() {
+		C.toggleKeyCode(code, true, flags, pid)
+	})
 	MilliSleep(3)
-	C.toggleKeyCode(code, false, flags, pid)
+	passInput(func() {
+		C.toggleKeyCode(code, false, flags, pid)
+	})
 }
 
 var keyErr = errors.New("Invalid key flag specified.")
@@ -408,7 +412,9 @@ func getFlagsFromValue(value []string) (flags C.MMKeyFlags) {
 func upKeyArr(keyArr []string, pid int) {
 	for i := 0; i < len(keyArr); i++ {
 		key1, _ := checkKeyCodes(keyArr[i])
-		C.toggleKeyCode(key1, false, C.MOD_NONE, C.uintptr(pid))
+		passInput(func() {
+			C.toggleKeyCode(key1, false, C.MOD_NONE, C.uintptr(pid))
+		})
 	}
 }
 
@@ -448,7 +454,9 @@ func keyTogglesB(k string, down bool, keyArr []string, pid int) error {
 		return err
 	}
 
-	C.toggleKeyCode(key, C.bool(down), flags, C.uintptr(pid))
+	passInput(func() {
+		C.toggleKeyCode(key, C.bool(down), flags, C.uintptr(pid))
+	})
 	MilliSleep(KeySleep)
 	if !down {
 		upKeyArr(keyArr, pid)
@@ -639,7 +647,9 @@ func UnicodeType(str uint32, args ...int) {
 		isPid = args[1]
 	}
 
-	C.unicodeType(cstr, C.uintptr(pid), C.int8_t(isPid))
+	passInput(func() {
+		C.unicodeType(cstr, C.uintptr(pid), C.int8_t(isPid))
+	})
 }
 
 // ToUC trans string to unicode []string
@@ -665,7 +675,9 @@ func ToUC(text string) []string {
 
 func inputUTF(str string) {
 	cstr := C.CString(str)
-	C.input_utf(cstr)
+	passInput(func() {
+		C.input_utf(cstr)
+	})
 
 	C.free(unsafe.Pointer(cstr))
 }
@@ -18,6 +18,7 @@
 	// #include </System/Library/Frameworks/ApplicationServices.framework/Headers/ApplicationServices.h>
 	#include <ApplicationServices/ApplicationServices.h>
 	// #include </System/Library/Frameworks/ApplicationServices.framework/Versions/A/Headers/ApplicationServices.h>
+	#include <AppKit/NSEvent.h>
 #elif defined(USE_X11)
 	#include <X11/Xlib.h>
 	#include <X11/extensions/XTest.h>
@@ -153,6 +154,38 @@ MMPointInt32 location() {
 	#endif
 }
 
+/* Move the mouse with the relative motion, not warp the pointer. */
+void moveMouseRelative(int32_t dx, int32_t dy){
+	#if defined(IS_MACOSX)
+		MMPointInt32 pos = location();
+		CGEventSourceRef source = CGEventSourceCreate(kCGEventSourceStateHIDSystemState);
+		CGEventRef move = CGEventCreateMouseEvent(source, kCGEventMouseMoved, 
+								CGPointMake(pos.x + dx, pos.y + dy), kCGMouseButtonLeft);
+
+		CGEventSetIntegerValueField(move, kCGMouseEventDeltaX, dx);
+		CGEventSetIntegerValueField(move, kCGMouseEventDeltaY, dy);
+		CGEventPost(kCGHIDEventTap, move);
+		CFRelease(move);
+		CFRelease(source);
+	#elif defined(USE_X11)
+		Display *display = XGetMainDisplay();
+		XTestFakeRelativeMotionEvent(display, dx, dy, CurrentTime);
+
+		XSync(display, false);
+	#elif defined(IS_WINDOWS)
+		INPUT mouseInput;
+
+		mouseInput.type = INPUT_MOUSE;
+		mouseInput.mi.dx = dx;
+		mouseInput.mi.dy = dy;
+		mouseInput.mi.dwFlags = MOUSEEVENTF_MOVE;
+		mouseInput.mi.time = 0;
+		mouseInput.mi.dwExtraInfo = 0;
+		mouseInput.mi.mouseData = 0;
+		SendInput(1, &mouseInput, sizeof(mouseInput));
+	#endif
+}
+
 /* Press down a button, or release it. */
 int toggleMouse(bool down, MMMouseButton button) {
 	#if defined(IS_MACOSX)
@@ -236,6 +269,31 @@ int doubleClick(MMMouseButton button, int count){
 	#endif
 }
 
+/* Get the system double click time in milliseconds, return 0 on X11. */
+int getDoubleClickTime(void) {
+	#if defined(IS_MACOSX)
+		return (int)([NSEvent doubleClickInterval] * 1000);
+	#elif defined(IS_WINDOWS)
+		return (int)GetDoubleClickTime();
+	#else
+		return 0;
+	#endif
+}
+
+/* Get the relative motion acceleration, return 1 if the system scales
+   the relative motion (Windows), return 0 on the others. */
+int getPointerAccel(void) {
+	#if defined(IS_WINDOWS)
+		int mouse[3] = {0, 0, 0};
+		int speed = 10;
+		SystemParametersInfo(SPI_GETMOUSE, 0, mouse, 0);
+		SystemParametersInfo(SPI_GETMOUSESPEED, 0, &speed, 0);
+		return mouse[2] != 0 || speed != 10;
+	#else
+		return 0;
+	#endif
+}
+
 /* Function used to scroll the screen in the required direction. */
 void scrollMouseXY(int x, int y) {
 	#if defined(IS_WINDOWS)
@@ -291,55 +349,3 @@ void scrollMouseXY(int x, int y) {
 		SendInput(1, &mouseScrollInputV, sizeof(mouseScrollInputV));
 	#endif
 }
-
-/* A crude, fast hypot() approximation to get around the fact that hypot() is not a standard ANSI C function. */
-#if !defined(M_SQRT2)
-	#define M_SQRT2 1.4142135623730950488016887 /* Fix for MSVC. */
-#endif
-
-static double crude_hypot(double x, double y){
-	double big = fabs(x); /* max(|x|, |y|) */
-	double small = fabs(y); /* min(|x|, |y|) */
-
-	if (big > small) {
-		double temp = big;
-		big = small;
-		small = temp;
-	}
-
-	return ((M_SQRT2 - 1.0) * small) + big;
-}
-
-bool smoothlyMoveMouse(MMPointInt32 endPoint, double lowSpeed, double highSpeed){
-	MMPointInt32 pos = location();
-	// MMSizeInt32 screenSize = getMainDisplaySize();
-	double velo_x = 0.0, velo_y = 0.0;
-	double distance;
-
-	while ((distance =crude_hypot((double)pos.x - endPoint.x, (double)pos.y - endPoint.y)) > 1.0) {
-		double gravity = DEADBEEF_UNIFORM(5.0, 500.0);
-		// double gravity = DEADBEEF_UNIFORM(lowSpeed, highSpeed);
-		double veloDistance;
-		velo_x += (gravity * ((double)endPoint.x - pos.x)) / distance;
-		velo_y += (gravity * ((double)endPoint.y - pos.y)) / distance;
-
-		/* Normalize velocity to get a unit vector of length 1. */
-		veloDistance = crude_hypot(velo_x, velo_y);
-		velo_x /= veloDistance;
-		velo_y /= veloDistance;
-
-		pos.x += floor(velo_x + 0.5);
-		pos.y += floor(velo_y + 0.5); 
-
-		/* Make sure we are in the screen boundaries! */
-		// if (pos.x >= screenSize.w || pos.y >= screenSize.h) { 
-		// 	return false;
-		// }
-		moveMouse(pos);
-
-		/* Wait 1 - 3 milliseconds. */
-		microsleep(DEADBEEF_UNIFORM(lowSpeed, highSpeed));
-		// microsleep(DEADBEEF_UNIFORM(1.0, 3.0));
-	}
-	return true;
-}
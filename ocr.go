@@ -0,0 +1,230 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"bufio"
+	"bytes"
+	"image"
+	"image/png"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TextBox is the OCR recognized text and the bounding box
+type TextBox struct {
+	Rect
+	Text string
+	// Conf is the confidence (0 ~ 100)
+	Conf float64
+	// Block, Par and Line is the layout index of the text
+	Block, Par, Line int
+}
+
+// ocrLang get the tesseract language, "zh" is the "chi_sim"
+func ocrLang(args []string) string {
+	lang := "eng"
+	if len(args) > 0 && args[0] != "" {
+		lang = args[0]
+		if lang == "zh" {
+			lang = "chi_sim"
+		}
+	}
+	return lang
+}
+
+// encodeOCR encode the image to the png for the tesseract
+func encodeOCR(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTSV parse the tesseract tsv output, return the words
+func parseTSV(b []byte) []TextBox {
+	var arr []TextBox
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		// level page block par line word left top width height conf text
+		f := strings.SplitN(sc.Text(), "\t", 12)
+		if len(f) < 12 || f[0] != "5" {
+			continue
+		}
+
+		var n [10]int
+		for i := range n {
+			n[i], _ = strconv.Atoi(f[i+1])
+		}
+		conf, _ := strconv.ParseFloat(f[10], 64)
+
+		text := strings.TrimSpace(f[11])
+		if text == "" {
+			continue
+		}
+		arr = append(arr, TextBox{
+			Rect:  Rect{Point{X: n[5], Y: n[6]}, Size{W: n[7], H: n[8]}},
+			Text:  text,
+			Conf:  conf,
+			Block: n[1], Par: n[2], Line: n[3],
+		})
+	}
+	return arr
+}
+
+// OCRWords recognize the words of the region by the tesseract ocr,
+// the region is the same as FindBitmap(), the boxes are the screen points,
+// it uses the shared OCREngine of the language
+//
+// robotgo.OCRWords(region interface{}, lang string)
+//
+// Examples:
+//
+//	words, err := robotgo.OCRWords(robotgo.Rect{
+//		Point: robotgo.Point{X: 0, Y: 0}, Size: robotgo.Size{W: 800, H: 100}})
+//	for _, w := range words {
+//		fmt.Println(w.Text, w.Rect, w.Conf)
+//	}
+func OCRWords(region interface{}, lang ...string) ([]TextBox, error) {
+	e := defaultOCR(lang)
+	defer e.release()
+	return e.Words(region)
+}
+
+// OCRLines recognize the text lines of the region, the same as OCRWords()
+func OCRLines(region interface{}, lang ...string) ([]TextBox, error) {
+	e := defaultOCR(lang)
+	defer e.release()
+	return e.Lines(region)
+}
+
+// GetImgText get the text of the region by the tesseract ocr,
+// the lines are joined by the "\n", no need to save the image file
+//
+// robotgo.GetImgText(region interface{}, lang string)
+func GetImgText(region interface{}, lang ...string) (string, error) {
+	e := defaultOCR(lang)
+	defer e.release()
+	return e.Text(region)
+}
+
+// TextLines group the words to the lines by the layout index,
+// the line confidence is the mean of the words
+func TextLines(words []TextBox) []TextBox {
+	var arr []TextBox
+	idx := make(map[[3]int]int)
+	for _, w := range words {
+		key := [3]int{w.Block, w.Par, w.Line}
+		i, ok := idx[key]
+		if !ok {
+			idx[key] = len(arr)
+			arr = append(arr, w)
+			continue
+		}
+
+		l := &arr[i]
+		n := float64(len(strings.Fields(l.Text)))
+		l.Conf = (l.Conf*n + w.Conf) / (n + 1)
+		l.Text += " " + w.Text
+		l.Rect = unionRect(l.Rect, w.Rect)
+	}
+	return arr
+}
+
+func unionRect(a, b Rect) Rect {
+	r := image.Rect(a.X, a.Y, a.X+a.W, a.Y+a.H).Union(
+		image.Rect(b.X, b.Y, b.X+b.W, b.Y+b.H))
+	return Rect{Point{X: r.Min.X, Y: r.Min.Y}, Size{W: r.Dx(), H: r.Dy()}}
+}
+
+// normWord normalize the word for the text compare,
+// the case and the edge punctuations are ignored
+func normWord(s string) string {
+	return strings.ToLower(strings.TrimFunc(s, unicode.IsPunct))
+}
+
+// matchWords find the consecutive words of the same line
+// matched the query words, the phrase Levenshtein distance
+// is within the maxDist, return the phrase boxes
+func matchWords(words []TextBox, query string, maxDist int) []TextBox {
+	q := strings.Fields(query)
+	for i := range q {
+		q[i] = normWord(q[i])
+	}
+	if len(q) == 0 {
+		return nil
+	}
+	phrase := strings.Join(q, " ")
+
+	var arr []TextBox
+	for i := 0; i+len(q) <= len(words); i++ {
+		arr1 := make([]string, len(q))
+		same := true
+		for j := range q {
+			same = same && sameLine(words[i+j], words[i])
+			arr1[j] = normWord(words[i+j].Text)
+		}
+
+		if same && levenshtein(strings.Join(arr1, " "), phrase) <= maxDist {
+			arr = append(arr, TextLines(words[i : i+len(q)])[0])
+		}
+	}
+	return arr
+}
+
+// levenshtein get the edit distance of the runes
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev, cur := make([]int, len(t)+1), make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(t)]
+}
+
+func sameLine(a, b TextBox) bool {
+	return a.Block == b.Block && a.Par == b.Par && a.Line == b.Line
+}
+
+// FindText find the text in the region by the tesseract ocr,
+// return the screen boxes of the matched words or the ErrNotFound,
+// the query can be the words of one line, the case and the
+// edge punctuations are ignored
+//
+// robotgo.FindText(query string, region interface{}, lang string)
+//
+// Examples:
+//
+//	arr, err := robotgo.FindText("Save as", nil)
+//	if err == nil {
+//		robotgo.Move(robotgo.ScreenToMove(arr[0].X+arr[0].W/2, arr[0].Y+arr[0].H/2))
+//	}
+func FindText(query string, region interface{}, lang ...string) ([]TextBox, error) {
+	e := defaultOCR(lang)
+	defer e.release()
+	return e.FindText(query, region)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"context"
+	"image"
+	"strconv"
+	"time"
+)
+
+// TesseractCLI is the OCR of the tesseract command, no cgo is needed,
+// it has no state, the commands can run in parallel
+type TesseractCLI struct {
+	// Path is the tesseract command path, default is "tesseract"
+	Path string
+	// Timeout is the command timeout (ms), the hung tesseract is killed,
+	// default is 60000, 0 or less is no timeout
+	Timeout int
+	args    []string
+}
+
+// NewTesseractCLI create the tesseract command OCR of the options,
+// the Filters and Backend are not used
+//
+// Examples:
+//
+//	robotgo.SetOCR(robotgo.NewTesseractCLI(robotgo.OCROpts{Lang: "eng+deu"}))
+func NewTesseractCLI(opts ...OCROpts) *TesseractCLI {
+	var opt OCROpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt.Lang = ocrLang([]string{opt.Lang})
+
+	return &TesseractCLI{Path: "tesseract", Timeout: 60000, args: tesseractArgs(opt)}
+}
+
+// tesseractArgs get the tesseract command arguments of the options,
+// the image is read from the stdin and the tsv is written to the stdout
+func tesseractArgs(opt OCROpts) []string {
+	args := []string{"stdin", "stdout", "-l", opt.Lang}
+	if opt.TessdataPrefix != "" {
+		args = append(args, "--tessdata-dir", opt.TessdataPrefix)
+	}
+	if opt.PageSegMode > 0 {
+		args = append(args, "--psm", strconv.Itoa(opt.PageSegMode))
+	}
+	if opt.EngineMode > 0 {
+		args = append(args, "--oem", strconv.Itoa(opt.EngineMode))
+	}
+	if opt.DPI > 0 {
+		args = append(args, "--dpi", strconv.Itoa(opt.DPI))
+	}
+	if opt.Whitelist != "" {
+		args = append(args, "-c", "tessedit_char_whitelist="+opt.Whitelist)
+	}
+	if opt.Blacklist != "" {
+		args = append(args, "-c", "tessedit_char_blacklist="+opt.Blacklist)
+	}
+	return append(args, "tsv")
+}
+
+// Recognize recognize the words by the tesseract command,
+// the image is passed by the stdin
+func (c *TesseractCLI) Recognize(img image.Image) ([]TextBox, error) {
+	b, err := encodeOCR(img)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.Timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	body, err := RunCmdContext(ctx, b, c.Path, c.args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseTSV(body), nil
+}
+
+// Close implement the OCR, nothing to close
+func (c *TesseractCLI) Close() error {
+	return nil
+}
@@ -0,0 +1,409 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"strings"
+	"sync"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// OCR is the ocr backend of the OCREngine and the text APIs,
+// implement it to use the other ocr engine
+type OCR interface {
+	// Recognize recognize the words of the image, the boxes are the image points
+	Recognize(img image.Image) ([]TextBox, error)
+	// Close free the backend
+	Close() error
+}
+
+// OCROpts is the OCREngine options, the zero value is the default
+type OCROpts struct {
+	// Lang is the tesseract language, e.g. "eng+deu", default is "eng",
+	// "zh" is the "chi_sim"
+	Lang string
+	// TessdataPrefix is the tessdata directory,
+	// default is the TESSDATA_PREFIX or the tesseract default
+	TessdataPrefix string
+	// PageSegMode is the tesseract page segmentation mode (--psm),
+	// default is the tesseract default (3, auto)
+	PageSegMode int
+	// EngineMode is the tesseract engine mode (--oem),
+	// default is the tesseract default (3), the `ocr` tag build
+	// (gosseract) returns an error if it's set
+	EngineMode int
+	// Whitelist and Blacklist is the recognized characters
+	Whitelist, Blacklist string
+	// DPI is the image resolution hint, default is the tesseract guess
+	DPI int
+	// Filters is the default image preprocessing pipeline
+	Filters []ImageFilter
+
+	// Backend is the ocr backend, the options above except the Filters
+	// are not used, default is the NewTesseract() of the options
+	Backend OCR
+}
+
+// OCREngine is the long-lived ocr engine with the preprocessing,
+// it's safe to use from multiple goroutines if the backend is safe,
+// the NewTesseract() and NewTesseractCLI() are safe
+//
+// Examples:
+//
+//	ocr := robotgo.NewOCREngine(robotgo.OCROpts{
+//		PageSegMode: 7, Whitelist: "0123456789",
+//		Filters: []robotgo.ImageFilter{robotgo.FilterUpscale(2), robotgo.FilterBinarize},
+//	})
+//	defer ocr.Close()
+//
+//	words, err := ocr.Words(robotgo.Rect{
+//		Point: robotgo.Point{X: 10, Y: 10}, Size: robotgo.Size{W: 100, H: 30}})
+//	// The dark theme region
+//	words, err = ocr.Words(nil, robotgo.FilterAutoInvert, robotgo.FilterGray)
+type OCREngine struct {
+	opt OCROpts
+	// own is true if the engine created the backend
+	own bool
+}
+
+// NewOCREngine create the ocr engine, call the Close() to free it
+func NewOCREngine(opts ...OCROpts) *OCREngine {
+	var opt OCROpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt.Lang = ocrLang([]string{opt.Lang})
+
+	e := &OCREngine{opt: opt}
+	if opt.Backend == nil {
+		e.opt.Backend, e.own = NewTesseract(opt), true
+	}
+	return e
+}
+
+// Opts return the engine options
+func (e *OCREngine) Opts() OCROpts {
+	return e.opt
+}
+
+// Close close the engine, the Backend of the options is not closed
+func (e *OCREngine) Close() error {
+	if !e.own {
+		return nil
+	}
+	return e.opt.Backend.Close()
+}
+
+// Recognize recognize the words of the image, the boxes are the image points,
+// the filters override the engine Filters
+func (e *OCREngine) Recognize(img image.Image, filters ...ImageFilter) ([]TextBox, error) {
+	src, err := toRGBA(img)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		filters = e.opt.Filters
+	}
+
+	dst := src
+	for _, f := range filters {
+		dst = f(dst)
+	}
+
+	words, err := e.opt.Backend.Recognize(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the boxes of the resized image to the source
+	sx := float64(src.Rect.Dx()) / float64(dst.Rect.Dx())
+	sy := float64(src.Rect.Dy()) / float64(dst.Rect.Dy())
+	if sx != 1 || sy != 1 {
+		for i := range words {
+			words[i].Rect = scaleRect(words[i].Rect, sx, sy)
+		}
+	}
+	return words, nil
+}
+
+func scaleRect(r Rect, sx, sy float64) Rect {
+	x0, y0 := math.Floor(float64(r.X)*sx), math.Floor(float64(r.Y)*sy)
+	x1 := math.Ceil(float64(r.X+r.W) * sx)
+	y1 := math.Ceil(float64(r.Y+r.H) * sy)
+	return Rect{Point{X: int(x0), Y: int(y0)}, Size{W: int(x1 - x0), H: int(y1 - y0)}}
+}
+
+// Words recognize the words of the region, the region is the same as
+// FindBitmap(), the boxes are the screen points
+func (e *OCREngine) Words(region interface{}, filters ...ImageFilter) ([]TextBox, error) {
+	src, o, err := regionRGBA(region)
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := e.Recognize(src, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range words {
+		words[i].X += o.X
+		words[i].Y += o.Y
+	}
+	return words, nil
+}
+
+// Lines recognize the text lines of the region
+func (e *OCREngine) Lines(region interface{}, filters ...ImageFilter) ([]TextBox, error) {
+	words, err := e.Words(region, filters...)
+	if err != nil {
+		return nil, err
+	}
+	return TextLines(words), nil
+}
+
+// Text recognize the text of the region, the lines are joined by the "\n"
+func (e *OCREngine) Text(region interface{}, filters ...ImageFilter) (string, error) {
+	lines, err := e.Lines(region, filters...)
+	if err != nil {
+		return "", err
+	}
+
+	arr := make([]string, len(lines))
+	for i, l := range lines {
+		arr[i] = l.Text
+	}
+	return strings.Join(arr, "\n"), nil
+}
+
+// FindText find the text in the region, the same as FindText()
+func (e *OCREngine) FindText(query string, region interface{}, filters ...ImageFilter) ([]TextBox, error) {
+	words, err := e.Words(region, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	arr := matchWords(words, query, 0)
+	if len(arr) == 0 {
+		return nil, ErrNotFound
+	}
+	return arr, nil
+}
+
+// sharedOCR is the shared engine of the text APIs, it's counted by
+// the users and closed after the last one if the SetOCR() replaced it
+type sharedOCR struct {
+	*OCREngine
+	refs    int
+	retired bool
+}
+
+var (
+	ocrMu      sync.Mutex
+	ocrEngines = map[string]*sharedOCR{}
+	ocrBackend OCR
+)
+
+// SetOCR set the ocr backend of the text APIs (OCRWords, FindText,
+// ClickText...), the language argument of them is not used,
+// nil is the default NewTesseract() of the language.
+// The replaced engines are closed after the running recognitions
+//
+// Examples:
+//
+//	robotgo.SetOCR(robotgo.NewTesseractCLI(robotgo.OCROpts{PageSegMode: 11}))
+//	defer robotgo.SetOCR(nil)
+func SetOCR(backend OCR) {
+	ocrMu.Lock()
+	old := ocrEngines
+	ocrEngines = map[string]*sharedOCR{}
+	ocrBackend = backend
+
+	var idle []*sharedOCR
+	for _, e := range old {
+		e.retired = true
+		if e.refs == 0 {
+			idle = append(idle, e)
+		}
+	}
+	ocrMu.Unlock()
+
+	for _, e := range idle {
+		e.Close()
+	}
+}
+
+// defaultOCR get the shared engine of the language,
+// call the release() after use
+func defaultOCR(lang []string) *sharedOCR {
+	l := ocrLang(lang)
+
+	ocrMu.Lock()
+	defer ocrMu.Unlock()
+	e, ok := ocrEngines[l]
+	if !ok {
+		e = &sharedOCR{OCREngine: NewOCREngine(OCROpts{Lang: l, Backend: ocrBackend})}
+		ocrEngines[l] = e
+	}
+	e.refs++
+	return e
+}
+
+// release the shared engine, close it if it's the last user of the retired
+func (e *sharedOCR) release() {
+	ocrMu.Lock()
+	e.refs--
+	done := e.retired && e.refs == 0
+	ocrMu.Unlock()
+
+	if done {
+		e.Close()
+	}
+}
+
+// ImageFilter is the ocr image preprocessing step,
+// it returns the new image and doesn't change the src
+type ImageFilter func(src *image.RGBA) *image.RGBA
+
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, src.Rect.Dx(), src.Rect.Dy()))
+	draw.Draw(dst, dst.Rect, src, src.Rect.Min, draw.Src)
+	return dst
+}
+
+// luma get the pixel luminance
+func luma(p []uint8) uint8 {
+	return uint8((299*int(p[0]) + 587*int(p[1]) + 114*int(p[2]) + 500) / 1000)
+}
+
+// FilterGray convert the image to the grayscale
+func FilterGray(src *image.RGBA) *image.RGBA {
+	dst := cloneRGBA(src)
+	for i := 0; i < len(dst.Pix); i += 4 {
+		y := luma(dst.Pix[i:])
+		dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2] = y, y, y
+	}
+	return dst
+}
+
+// FilterInvert invert the image colors
+func FilterInvert(src *image.RGBA) *image.RGBA {
+	dst := cloneRGBA(src)
+	for i := 0; i < len(dst.Pix); i += 4 {
+		dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2] =
+			255-dst.Pix[i], 255-dst.Pix[i+1], 255-dst.Pix[i+2]
+	}
+	return dst
+}
+
+// FilterAutoInvert invert the dark image (the dark theme),
+// the tesseract works best with the dark text on the light background
+func FilterAutoInvert(src *image.RGBA) *image.RGBA {
+	var sum, n int
+	for y := 0; y < src.Rect.Dy(); y++ {
+		row := src.Pix[y*src.Stride : y*src.Stride+src.Rect.Dx()*4]
+		for i := 0; i < len(row); i += 4 {
+			sum += int(luma(row[i:]))
+			n++
+		}
+	}
+
+	if n > 0 && sum/n < 128 {
+		return FilterInvert(src)
+	}
+	return cloneRGBA(src)
+}
+
+// FilterBinarize binarize the image by the Otsu threshold
+func FilterBinarize(src *image.RGBA) *image.RGBA {
+	return FilterThreshold(0)(src)
+}
+
+// FilterThreshold binarize the image by the luminance level,
+// 0 is the Otsu threshold
+func FilterThreshold(level uint8) ImageFilter {
+	return func(src *image.RGBA) *image.RGBA {
+		dst := FilterGray(src)
+		t := level
+		if t == 0 {
+			t = otsu(dst)
+		}
+
+		for i := 0; i < len(dst.Pix); i += 4 {
+			v := uint8(0)
+			if dst.Pix[i] > t {
+				v = 255
+			}
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2] = v, v, v
+		}
+		return dst
+	}
+}
+
+// otsu get the Otsu threshold of the grayscale image
+func otsu(gray *image.RGBA) uint8 {
+	var hist [256]int
+	for i := 0; i < len(gray.Pix); i += 4 {
+		hist[gray.Pix[i]]++
+	}
+
+	total, sum := 0, 0
+	for i, n := range hist {
+		total += n
+		sum += i * n
+	}
+
+	var (
+		best     float64
+		t        uint8
+		wb, sumB int
+	)
+	for i, n := range hist {
+		wb += n
+		wf := total - wb
+		if wb == 0 {
+			continue
+		}
+		if wf == 0 {
+			break
+		}
+
+		sumB += i * n
+		mb := float64(sumB) / float64(wb)
+		mf := float64(sum-sumB) / float64(wf)
+		v := float64(wb) * float64(wf) * (mb - mf) * (mb - mf)
+		if v > best {
+			best, t = v, uint8(i)
+		}
+	}
+	return t
+}
+
+// FilterUpscale resize the image by the scale, the tesseract works best
+// with the 20 ~ 30 pixels text height, e.g. 2 for the small UI fonts
+func FilterUpscale(scale float64) ImageFilter {
+	return func(src *image.RGBA) *image.RGBA {
+		w := int(math.Round(float64(src.Rect.Dx()) * scale))
+		h := int(math.Round(float64(src.Rect.Dy()) * scale))
+		if w <= 0 || h <= 0 {
+			return cloneRGBA(src)
+		}
+
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		xdraw.CatmullRom.Scale(dst, dst.Rect, src, src.Rect, xdraw.Src, nil)
+		return dst
+	}
+}
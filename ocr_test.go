@@ -0,0 +1,290 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vcaesar/tt"
+)
+
+const testTSV = `level	page_num	block_num	par_num	line_num	word_num	left	top	width	height	conf	text
+1	1	0	0	0	0	0	0	400	100	-1
+2	1	1	0	0	0	10	10	300	60	-1
+4	1	1	1	1	0	10	10	200	20	-1
+5	1	1	1	1	1	10	10	40	20	96	File
+5	1	1	1	1	2	60	12	60	18	91	Save:
+5	1	1	1	1	3	130	10	30	20	89	as
+4	1	1	1	2	0	10	50	100	20	-1
+5	1	1	1	2	1	10	50	50	20	95	Save
+5	1	1	1	2	2	70	50	10	20	12
+`
+
+func TestParseTSV(t *testing.T) {
+	words := parseTSV([]byte(testTSV))
+	tt.Equal(t, 4, len(words))
+	tt.Equal(t, TextBox{Rect: Rect{Point{60, 12}, Size{60, 18}}, Text: "Save:",
+		Conf: 91, Block: 1, Par: 1, Line: 1}, words[1])
+
+	lines := TextLines(words)
+	tt.Equal(t, 2, len(lines))
+	tt.Equal(t, "File Save: as", lines[0].Text)
+	tt.Equal(t, Rect{Point{10, 10}, Size{150, 20}}, lines[0].Rect)
+	tt.Equal(t, 92.0, lines[0].Conf)
+	tt.Equal(t, "Save", lines[1].Text)
+
+	tt.Equal(t, "chi_sim", ocrLang([]string{"zh"}))
+	tt.Equal(t, "eng", ocrLang(nil))
+}
+
+func TestMatchWords(t *testing.T) {
+	words := parseTSV([]byte(testTSV))
+
+	arr := matchWords(words, "save", 0)
+	tt.Equal(t, 2, len(arr))
+	tt.Equal(t, Rect{Point{60, 12}, Size{60, 18}}, arr[0].Rect)
+	tt.Equal(t, Rect{Point{10, 50}, Size{50, 20}}, arr[1].Rect)
+
+	arr = matchWords(words, "SAVE as", 0)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, "Save: as", arr[0].Text)
+	tt.Equal(t, Rect{Point{60, 10}, Size{100, 20}}, arr[0].Rect)
+
+	// The words of the different lines are not the phrase
+	tt.Equal(t, 0, len(matchWords(words, "as save", 0)))
+	tt.Equal(t, 0, len(matchWords(words, " ", 0)))
+
+	tt.Equal(t, 0, len(matchWords(words, "sve as", 0)))
+	arr = matchWords(words, "sve as", 1)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, "Save: as", arr[0].Text)
+	tt.Equal(t, 1, len(matchWords(words, "Fil", 1)))
+	tt.Equal(t, 3, len(matchWords(words, "sav", 2)))
+
+	tt.Equal(t, 0, levenshtein("", ""))
+	tt.Equal(t, 3, levenshtein("kitten", "sitting"))
+	tt.Equal(t, 1, levenshtein("保存", "保"))
+}
+
+func TestTesseractArgs(t *testing.T) {
+	e := NewOCREngine(OCROpts{Lang: "zh", PageSegMode: 7, DPI: 300, Whitelist: "0123456789"})
+	defer e.Close()
+
+	tt.Equal(t, "chi_sim", e.Opts().Lang)
+	tt.Equal(t, []string{"stdin", "stdout", "-l", "chi_sim", "--psm", "7", "--dpi", "300",
+		"-c", "tessedit_char_whitelist=0123456789", "tsv"}, tesseractArgs(e.Opts()))
+	tt.Equal(t, "eng", NewOCREngine().Opts().Lang)
+}
+
+func TestImageFilter(t *testing.T) {
+	// The dark theme: the light gray text on the dark background
+	src := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	draw.Draw(src, src.Rect, image.NewUniform(color.RGBA{0x20, 0x24, 0x28, 0xff}),
+		image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(4, 3, 8, 7), image.NewUniform(color.RGBA{0xc0, 0xc0, 0xb0, 0xff}),
+		image.Point{}, draw.Src)
+
+	g := FilterGray(src)
+	tt.Equal(t, color.RGBA{0x23, 0x23, 0x23, 0xff}, g.RGBAAt(0, 0))
+	tt.Equal(t, color.RGBA{0x20, 0x24, 0x28, 0xff}, src.RGBAAt(0, 0))
+
+	inv := FilterAutoInvert(src)
+	tt.Equal(t, color.RGBA{0xdf, 0xdb, 0xd7, 0xff}, inv.RGBAAt(0, 0))
+	tt.Equal(t, inv.Pix, FilterAutoInvert(inv).Pix)
+
+	bin := FilterBinarize(inv)
+	tt.Equal(t, color.RGBA{0xff, 0xff, 0xff, 0xff}, bin.RGBAAt(0, 0))
+	tt.Equal(t, color.RGBA{0, 0, 0, 0xff}, bin.RGBAAt(5, 5))
+	tt.Equal(t, color.RGBA{0, 0, 0, 0xff}, FilterThreshold(0xf0)(inv).RGBAAt(0, 0))
+
+	up := FilterUpscale(2.5)(src)
+	tt.Equal(t, image.Rect(0, 0, 50, 25), up.Rect)
+	tt.Equal(t, Rect{Point{4, 2}, Size{3, 2}}, scaleRect(Rect{Point{10, 5}, Size{7, 5}}, 0.4, 0.4))
+}
+
+func TestPickText(t *testing.T) {
+	arr := matchWords(parseTSV([]byte(testTSV)), "save", 0)
+
+	m, ok := pickText(arr, TextOpts{})
+	tt.True(t, ok)
+	tt.Equal(t, 12, m.Y)
+	m, ok = pickText(arr, TextOpts{Nth: 1})
+	tt.True(t, ok)
+	tt.Equal(t, 50, m.Y)
+	_, ok = pickText(arr, TextOpts{Nth: 2})
+	tt.False(t, ok)
+
+	m, ok = pickText(arr, TextOpts{Near: &Point{X: 0, Y: 100}})
+	tt.True(t, ok)
+	tt.Equal(t, Point{X: 35, Y: 60}, m.center())
+	m, _ = pickText(arr, TextOpts{Near: &Point{X: 0, Y: 100}, Nth: 1})
+	tt.Equal(t, 12, m.Y)
+}
+
+// fakeOCR is the OCR of the fixed words, the words are scaled
+// by the image width to the base width
+type fakeOCR struct {
+	words []TextBox
+	base  int
+	n     int
+	imgs  []image.Rectangle
+
+	closed int
+}
+
+func (f *fakeOCR) Recognize(img image.Image) ([]TextBox, error) {
+	f.imgs = append(f.imgs, img.Bounds())
+	f.n++
+
+	s := float64(img.Bounds().Dx()) / float64(f.base)
+	arr := make([]TextBox, len(f.words))
+	for i, w := range f.words {
+		w.Rect = scaleRect(w.Rect, s, s)
+		arr[i] = w
+	}
+	return arr, nil
+}
+
+func (f *fakeOCR) Close() error {
+	f.closed++
+	return nil
+}
+
+func TestOCREngine(t *testing.T) {
+	fake := &fakeOCR{words: parseTSV([]byte(testTSV)), base: 400}
+	e := NewOCREngine(OCROpts{Backend: fake, Filters: []ImageFilter{FilterUpscale(2)}})
+	defer e.Close()
+
+	src := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	words, err := e.Recognize(src)
+	tt.Nil(t, err)
+	tt.Equal(t, image.Rect(0, 0, 800, 200), fake.imgs[0])
+	tt.Equal(t, fake.words, words)
+
+	words, err = e.Recognize(src, FilterGray)
+	tt.Nil(t, err)
+	tt.Equal(t, image.Rect(0, 0, 400, 100), fake.imgs[1])
+	tt.Equal(t, fake.words, words)
+
+	text, err := e.Text(src)
+	tt.Nil(t, err)
+	tt.Equal(t, "File Save: as\nSave", text)
+
+	arr, err := e.FindText("save as", src)
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(arr))
+	_, err = e.FindText("open", src)
+	tt.Equal(t, ErrNotFound, err)
+}
+
+func TestTextAPI(t *testing.T) {
+	fake := &fakeOCR{words: parseTSV([]byte(testTSV)), base: 400}
+	SetOCR(fake)
+	defer SetOCR(nil)
+
+	src := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	words, err := OCRWords(src, "deu")
+	tt.Nil(t, err)
+	tt.Equal(t, 4, len(words))
+
+	arr, err := FindText("file", src)
+	tt.Nil(t, err)
+	tt.Equal(t, Rect{Point{10, 10}, Size{40, 20}}, arr[0].Rect)
+
+	box, err := LocateText("sav", TextOpts{Region: src, MaxDist: 1, Near: &Point{X: 0, Y: 100}})
+	tt.Nil(t, err)
+	tt.Equal(t, 50, box.Y)
+	_, err = LocateText("sav", TextOpts{Region: src})
+	tt.Equal(t, ErrNotFound, err)
+
+	box, err = WaitForText("save as", TextOpts{Region: src})
+	tt.Nil(t, err)
+	tt.Equal(t, "Save: as", box.Text)
+
+	n := fake.n
+	_, err = WaitForText("open", TextOpts{Region: src, Timeout: 50, Interval: 10})
+	e, ok := err.(*TimeoutError)
+	tt.True(t, ok)
+	tt.Equal(t, "WaitForText", e.Op)
+	tt.True(t, fake.n-n > 1)
+}
+
+func TestSetOCRRelease(t *testing.T) {
+	fake := &fakeOCR{}
+	SetOCR(fake)
+	defer SetOCR(nil)
+
+	// The engine owns the backend, the default tesseract engine does
+	e := defaultOCR(nil)
+	e.own = true
+	tt.Equal(t, e, defaultOCR(nil))
+
+	SetOCR(fake)
+	tt.Equal(t, 0, fake.closed)
+	e.release()
+	tt.Equal(t, 0, fake.closed)
+	e.release()
+	tt.Equal(t, 1, fake.closed)
+
+	// The idle engine is closed by the SetOCR()
+	e = defaultOCR(nil)
+	e.own = true
+	e.release()
+	SetOCR(fake)
+	tt.Equal(t, 2, fake.closed)
+}
+
+func TestTesseractCLI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake tesseract is the shell script")
+	}
+
+	// The fake tesseract check the png stdin and print the tsv
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tesseract")
+	script := "#!/bin/sh\nhead -c 4 | grep -q PNG || { echo 'bad image' >&2; exit 1; }\n" +
+		"cat <<'EOF'\n" + testTSV + "EOF\n"
+	tt.Nil(t, os.WriteFile(path, []byte(script), 0755))
+
+	c := NewTesseractCLI()
+	c.Path = path
+	words, err := c.Recognize(image.NewRGBA(image.Rect(0, 0, 40, 10)))
+	tt.Nil(t, err)
+	tt.Equal(t, parseTSV([]byte(testTSV)), words)
+
+	_, err = RunCmd([]byte("GIF"), path)
+	tt.NotNil(t, err)
+	tt.True(t, strings.HasSuffix(err.Error(), "bad image"))
+	var exitErr *exec.ExitError
+	tt.True(t, errors.As(err, &exitErr))
+	tt.Equal(t, 1, exitErr.ExitCode())
+
+	// The hung tesseract is killed
+	hung := filepath.Join(dir, "hung")
+	tt.Nil(t, os.WriteFile(hung, []byte("#!/bin/sh\nexec sleep 10\n"), 0755))
+	c.Path, c.Timeout = hung, 100
+	start := time.Now()
+	_, err = c.Recognize(image.NewRGBA(image.Rect(0, 0, 40, 10)))
+	tt.True(t, errors.Is(err, context.DeadlineExceeded))
+	tt.True(t, time.Since(start) < 5*time.Second)
+}
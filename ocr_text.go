@@ -0,0 +1,198 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"sort"
+)
+
+// TextOpts is the locate by text options, the zero value is the default
+type TextOpts struct {
+	// Region is the search region, the same as FindBitmap(),
+	// default is the Window bounds or the screen
+	Region interface{}
+	// Window is the window pid, search in the window bounds (GetBounds)
+	Window int
+
+	// MaxDist is the max Levenshtein distance of the text,
+	// default is 0, the case and the edge punctuations are ignored
+	MaxDist int
+	// Nth is the index of the matches, in the reading order
+	// or the distance to the Near point, default is 0 (the first)
+	Nth int
+	// Near choose the matches nearest to the point first
+	Near *Point
+
+	// Engine is the ocr engine, default is the shared engine of the Lang
+	Engine *OCREngine
+	// Lang is the ocr language of the shared engine, default is "eng"
+	Lang string
+	// Filters is the image preprocessing of the search,
+	// default is the engine Filters
+	Filters []ImageFilter
+
+	// Button is the ClickText mouse button, default is "left"
+	Button string
+	// Double is the ClickText double click
+	Double bool
+
+	// Timeout and Interval is the WaitForText wait options (ms),
+	// default is the WaitOpts default
+	Timeout, Interval int
+}
+
+// textOpt get the options with the default, call the release() after use
+func textOpt(opts []TextOpts) (opt TextOpts, release func()) {
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	release = func() {}
+	if opt.Engine == nil {
+		e := defaultOCR([]string{opt.Lang})
+		opt.Engine, release = e.OCREngine, e.release
+	}
+	if opt.Button == "" {
+		opt.Button = "left"
+	}
+	if opt.Region == nil && opt.Window != 0 {
+		x, y, w, h := GetBounds(opt.Window)
+		opt.Region = Rect{Point{X: x, Y: y}, Size{W: w, H: h}}
+	}
+	return opt, release
+}
+
+// pickText choose the match by the Near and Nth rules
+func pickText(arr []TextBox, opt TextOpts) (TextBox, bool) {
+	if opt.Near != nil {
+		p := image.Pt(opt.Near.X, opt.Near.Y)
+		sort.SliceStable(arr, func(i, j int) bool {
+			ci, cj := arr[i].center(), arr[j].center()
+			return dist2(p, ci.X, ci.Y) < dist2(p, cj.X, cj.Y)
+		})
+	}
+
+	if opt.Nth < 0 || opt.Nth >= len(arr) {
+		return TextBox{}, false
+	}
+	return arr[opt.Nth], true
+}
+
+func (b TextBox) center() Point {
+	return Point{X: b.X + b.W/2, Y: b.Y + b.H/2}
+}
+
+// locateText recognize the src and pick the text match
+func locateText(query string, src *image.RGBA, o Point, opt TextOpts) (TextBox, error) {
+	words, err := opt.Engine.Recognize(src, opt.Filters...)
+	if err != nil {
+		return TextBox{}, err
+	}
+	for i := range words {
+		words[i].X += o.X
+		words[i].Y += o.Y
+	}
+
+	m, ok := pickText(matchWords(words, query, opt.MaxDist), opt)
+	if !ok {
+		return TextBox{}, ErrNotFound
+	}
+	return m, nil
+}
+
+// LocateText find the text by the ocr and return the screen box,
+// the query can be the words of one line, use the opts to choose
+// the match, return the ErrNotFound if there is no match
+//
+// robotgo.LocateText(query string, opts TextOpts)
+//
+// Examples:
+//
+//	box, err := robotgo.LocateText("Save", robotgo.TextOpts{
+//		Window: pid, MaxDist: 1, Near: &robotgo.Point{X: 800, Y: 600}})
+func LocateText(query string, opts ...TextOpts) (TextBox, error) {
+	opt, release := textOpt(opts)
+	defer release()
+	src, o, err := regionRGBA(opt.Region)
+	if err != nil {
+		return TextBox{}, err
+	}
+
+	box, err := locateText(query, src, o, opt)
+	switch err {
+	case nil:
+		debugFind("LocateText", opt.Region, src, o, func(a *Annotator) {
+			a.Rect(box.Rect, annotMatch, query)
+		})
+	case ErrNotFound:
+		debugFind("LocateText", opt.Region, src, o, nil)
+	}
+	return box, err
+}
+
+// MoveToText move the mouse to the center of the text,
+// the same as LocateText()
+func MoveToText(query string, opts ...TextOpts) (TextBox, error) {
+	box, err := LocateText(query, opts...)
+	if err != nil {
+		return box, err
+	}
+
+	// The box is the capture pixels
+	c := box.center()
+	Move(ScreenToMove(c.X, c.Y))
+	return box, nil
+}
+
+// ClickText move the mouse to the text and click,
+// the same as LocateText()
+//
+// Examples:
+//
+//	robotgo.ClickText("Save")
+//	robotgo.ClickText("File", robotgo.TextOpts{Button: "right"})
+func ClickText(query string, opts ...TextOpts) (TextBox, error) {
+	opt, release := textOpt(opts)
+	defer release()
+	box, err := MoveToText(query, opt)
+	if err != nil {
+		return box, err
+	}
+
+	MilliSleep(MouseSleep)
+	return box, Click(opt.Button, opt.Double)
+}
+
+// WaitForText wait the text appear, return the box or the *TimeoutError,
+// the same as LocateText()
+//
+// Examples:
+//
+//	box, err := robotgo.WaitForText("Done", robotgo.TextOpts{Timeout: 30000})
+func WaitForText(query string, opts ...TextOpts) (TextBox, error) {
+	opt, release := textOpt(opts)
+	defer release()
+	wopt := waitOpt([]WaitOpts{{Timeout: opt.Timeout, Interval: opt.Interval}})
+
+	var (
+		box  TextBox
+		ferr error
+	)
+	err := waitFor("WaitForText", opt.Region, wopt, func(src *image.RGBA, o Point) bool {
+		box, ferr = locateText(query, src, o, opt)
+		return ferr != ErrNotFound
+	})
+	if err != nil {
+		return TextBox{}, err
+	}
+	return box, ferr
+}
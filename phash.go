@@ -0,0 +1,379 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var errNoStates = errors.New("there are no states")
+
+// ImgHash is the 64 bits perceptual hash of the image,
+// the similar images have the near hashes
+type ImgHash uint64
+
+// Distance get the Hamming distance of the hashes (0 ~ 64),
+// e.g. the same screen is less than 10 usually
+func (h ImgHash) Distance(h1 ImgHash) int {
+	return bits.OnesCount64(uint64(h ^ h1))
+}
+
+// String return the 16 hex digits of the hash
+func (h ImgHash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// MarshalText implement the encoding.TextMarshaler
+func (h ImgHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implement the encoding.TextUnmarshaler
+func (h *ImgHash) UnmarshalText(b []byte) error {
+	h1, err := ParseImgHash(string(b))
+	if err != nil {
+		return err
+	}
+	*h = h1
+	return nil
+}
+
+// ParseImgHash parse the hex hash string
+func ParseImgHash(s string) (ImgHash, error) {
+	u, err := strconv.ParseUint(s, 16, 64)
+	return ImgHash(u), err
+}
+
+// HammingDistance get the Hamming distance of the hashes
+func HammingDistance(h1, h2 ImgHash) int {
+	return h1.Distance(h2)
+}
+
+// grayResize resize the image to the w * h luminance by the area average
+func grayResize(src *image.RGBA, w, h int) []float64 {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	arr := make([]float64, w*h)
+	if sw == 0 || sh == 0 {
+		return arr
+	}
+
+	for y := 0; y < h; y++ {
+		y0 := y * sh / h
+		y1 := max((y+1)*sh/h, y0+1)
+		for x := 0; x < w; x++ {
+			x0 := x * sw / w
+			x1 := max((x+1)*sw/w, x0+1)
+
+			var sum int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					sum += int(luma(row[sx*4:]))
+				}
+			}
+			arr[y*w+x] = float64(sum) / float64((x1-x0)*(y1-y0))
+		}
+	}
+	return arr
+}
+
+// hashRegion get the hash of the region by the hash function
+func hashRegion(region interface{}, fn func(src *image.RGBA) ImgHash) (ImgHash, error) {
+	src, _, err := regionRGBA(region)
+	if err != nil {
+		return 0, err
+	}
+	return fn(src), nil
+}
+
+// AHash get the average hash of the region, the region is the same
+// as FindBitmap(), it's the fastest and the least robust hash
+func AHash(region interface{}) (ImgHash, error) {
+	return hashRegion(region, aHash)
+}
+
+func aHash(src *image.RGBA) ImgHash {
+	arr := grayResize(src, 8, 8)
+	var mean float64
+	for _, v := range arr {
+		mean += v
+	}
+	mean /= 64
+
+	var h ImgHash
+	for i, v := range arr {
+		if v > mean {
+			h |= 1 << i
+		}
+	}
+	return h
+}
+
+// DHash get the difference hash of the region by the horizontal gradients,
+// it's robust to the brightness and the contrast changes
+func DHash(region interface{}) (ImgHash, error) {
+	return hashRegion(region, dHash)
+}
+
+func dHash(src *image.RGBA) ImgHash {
+	arr := grayResize(src, 9, 8)
+
+	var h ImgHash
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if arr[y*9+x] < arr[y*9+x+1] {
+				h |= 1 << (y*8 + x)
+			}
+		}
+	}
+	return h
+}
+
+// PHash get the perceptual hash of the region by the DCT low frequencies,
+// it's the most robust hash, the small text and the anti-aliasing changes
+// only change a few bits
+//
+// Examples:
+//
+//	h1, _ := robotgo.PHash(robotgo.Rect{
+//		Point: robotgo.Point{X: 0, Y: 0}, Size: robotgo.Size{W: 800, H: 600}})
+//	h2, _ := robotgo.PHash(img)
+//	fmt.Println(h1.Distance(h2))
+func PHash(region interface{}) (ImgHash, error) {
+	return hashRegion(region, pHash)
+}
+
+// dctCos is the DCT-II cosine table of the 32 points, only the
+// 8 low frequencies are used
+var dctCos = func() (t [8][32]float64) {
+	for u := range t {
+		for x := range t[u] {
+			t[u][x] = math.Cos(float64((2*x+1)*u) * math.Pi / 64)
+		}
+	}
+	return
+}()
+
+func pHash(src *image.RGBA) ImgHash {
+	arr := grayResize(src, 32, 32)
+
+	// The separable DCT of the rows, then the columns
+	var rows [32][8]float64
+	for y := 0; y < 32; y++ {
+		for u := 0; u < 8; u++ {
+			var s float64
+			for x := 0; x < 32; x++ {
+				s += arr[y*32+x] * dctCos[u][x]
+			}
+			rows[y][u] = s
+		}
+	}
+
+	var coef [64]float64
+	for v := 0; v < 8; v++ {
+		for u := 0; u < 8; u++ {
+			var s float64
+			for y := 0; y < 32; y++ {
+				s += rows[y][u] * dctCos[v][y]
+			}
+			coef[v*8+u] = s
+		}
+	}
+
+	// The median without the DC
+	sorted := make([]float64, 63)
+	copy(sorted, coef[1:])
+	sort.Float64s(sorted)
+	median := (sorted[30] + sorted[31]) / 2
+
+	// The bit 0 is the DC, it's the sum of the 32 * 32 pixels,
+	// set if the image is brighter than the middle gray
+	var h ImgHash
+	if coef[0] > 32*32*127.5 {
+		h |= 1
+	}
+	for i := 1; i < 64; i++ {
+		if coef[i] > median {
+			h |= 1 << i
+		}
+	}
+	return h
+}
+
+// HashFunc is the region hash function, e.g. PHash
+type HashFunc func(region interface{}) (ImgHash, error)
+
+// StateStore is the store of the named fingerprints, the state can
+// have some samples, it's safe to use from multiple goroutines
+//
+// Examples:
+//
+//	store := robotgo.NewStateStore()
+//	store.Add("login", loginImg)
+//	store.Add("main", mainImg)
+//
+//	name, dist, err := store.Detect(nil)
+//	if err == nil && dist <= 10 {
+//		fmt.Println("the screen is", name)
+//	}
+type StateStore struct {
+	hash HashFunc
+
+	mu     sync.RWMutex
+	states map[string][]ImgHash
+}
+
+// NewStateStore create the state store, the hash default is the PHash,
+// the saved stores should be loaded with the same hash
+func NewStateStore(hash ...HashFunc) *StateStore {
+	s := &StateStore{hash: PHash, states: make(map[string][]ImgHash)}
+	if len(hash) > 0 && hash[0] != nil {
+		s.hash = hash[0]
+	}
+	return s
+}
+
+// Add add the region fingerprint as the state sample,
+// the region is the same as FindBitmap()
+func (s *StateStore) Add(name string, region interface{}) (ImgHash, error) {
+	h, err := s.hash(region)
+	if err != nil {
+		return 0, err
+	}
+
+	s.AddHash(name, h)
+	return h, nil
+}
+
+// AddHash add the fingerprint as the state sample
+func (s *StateStore) AddHash(name string, h ImgHash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[name] = append(s.states[name], h)
+}
+
+// Remove remove the state and the samples
+func (s *StateStore) Remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, name)
+}
+
+// Names return the sorted state names
+func (s *StateStore) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	arr := make([]string, 0, len(s.states))
+	for name := range s.states {
+		arr = append(arr, name)
+	}
+	sort.Strings(arr)
+	return arr
+}
+
+// Closest return the state of the closest sample and the distance,
+// the ties are the first name
+func (s *StateStore) Closest(h ImgHash) (string, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name, dist := "", 65
+	for n, arr := range s.states {
+		for _, h1 := range arr {
+			d := h.Distance(h1)
+			if d < dist || d == dist && n < name {
+				name, dist = n, d
+			}
+		}
+	}
+
+	if dist > 64 {
+		return "", 0, errNoStates
+	}
+	return name, dist, nil
+}
+
+// Detect hash the region and return the closest state and the distance,
+// check the distance with the threshold, e.g. 10
+func (s *StateStore) Detect(region interface{}) (string, int, error) {
+	h, err := s.hash(region)
+	if err != nil {
+		return "", 0, err
+	}
+	return s.Closest(h)
+}
+
+// Save save the fingerprints to the json file
+func (s *StateStore) Save(path string) error {
+	s.mu.RLock()
+	b, err := json.MarshalIndent(s.states, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0644)
+}
+
+// Load load the fingerprints of the json file, add the samples to the store
+func (s *StateStore) Load(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var m map[string][]ImgHash
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, arr := range m {
+		s.states[name] = append(s.states[name], arr...)
+	}
+	return nil
+}
+
+// States is the state store of the AddState() and the DetectState()
+var States = NewStateStore()
+
+// AddState add the region fingerprint to the States,
+// the same as the StateStore.Add()
+//
+// Examples:
+//
+//	robotgo.AddState("dialog", robotgo.Rect{
+//		Point: robotgo.Point{X: 400, Y: 300}, Size: robotgo.Size{W: 300, H: 200}})
+func AddState(name string, region interface{}) (ImgHash, error) {
+	return States.Add(name, region)
+}
+
+// DetectState return the closest state of the States and the distance,
+// the region is the same as FindBitmap()
+//
+// Examples:
+//
+//	name, dist, err := robotgo.DetectState(nil)
+func DetectState(region interface{}) (string, int, error) {
+	return States.Detect(region)
+}
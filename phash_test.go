@@ -0,0 +1,111 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"path/filepath"
+	"testing"
+
+	"github.com/vcaesar/tt"
+	xdraw "golang.org/x/image/draw"
+)
+
+// testDialog make the dialog like image, the clock text is changed by the n
+func testDialog(w, h, n int) *image.RGBA {
+	img := testScreen(w, h)
+	draw.Draw(img, image.Rect(w/4, h/4, w*3/4, h*3/4),
+		image.NewUniform(color.RGBA{0xf8, 0xf8, 0xf8, 0xff}), image.Point{}, draw.Src)
+	testButton(img, w/2, h*3/4-30)
+
+	for i := 0; i < 4; i++ {
+		x := w/4 + 10 + i*8
+		draw.Draw(img, image.Rect(x, h/4+10, x+6, h/4+10+(n+i)%10+2),
+			image.NewUniform(color.Black), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func TestImgHash(t *testing.T) {
+	a, b := testDialog(400, 300, 1), testDialog(400, 300, 7)
+	other := testScreen(400, 300)
+
+	small := image.NewRGBA(image.Rect(0, 0, 200, 150))
+	xdraw.BiLinear.Scale(small, small.Rect, a, a.Rect, xdraw.Src, nil)
+
+	for _, fn := range []HashFunc{AHash, DHash, PHash} {
+		ha, err := fn(a)
+		tt.Nil(t, err)
+		hb, _ := fn(b)
+		hs, _ := fn(small)
+		ho, _ := fn(other)
+
+		same, _ := fn(a.SubImage(a.Rect))
+		tt.Equal(t, 0, ha.Distance(same))
+		tt.True(t, ha.Distance(hb) <= 10)
+		tt.True(t, ha.Distance(hs) <= 10)
+		tt.True(t, ha.Distance(ho) > 10)
+	}
+
+	h, err := ParseImgHash("00ff00ff00ff00ff")
+	tt.Nil(t, err)
+	tt.Equal(t, "00ff00ff00ff00ff", h.String())
+	tt.Equal(t, 64, HammingDistance(h, ^h))
+	_, err = ParseImgHash("xyz")
+	tt.NotNil(t, err)
+
+	// The empty image
+	tt.Equal(t, ImgHash(0), aHash(image.NewRGBA(image.Rect(0, 0, 0, 0))))
+
+	// The bit 0 of the pHash is the brightness
+	tt.Equal(t, ImgHash(1), pHash(testDialog(400, 300, 1))&1)
+	tt.Equal(t, ImgHash(0), pHash(image.NewRGBA(image.Rect(0, 0, 64, 64)))&1)
+}
+
+func TestStateStore(t *testing.T) {
+	s := NewStateStore()
+	_, _, err := s.Detect(testScreen(200, 100))
+	tt.Equal(t, errNoStates, err)
+
+	_, err = s.Add("dialog", testDialog(400, 300, 1))
+	tt.Nil(t, err)
+	_, err = s.Add("dialog", testDialog(400, 300, 2))
+	tt.Nil(t, err)
+	_, err = s.Add("desktop", testScreen(400, 300))
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"desktop", "dialog"}, s.Names())
+
+	name, dist, err := s.Detect(testDialog(400, 300, 5))
+	tt.Nil(t, err)
+	tt.Equal(t, "dialog", name)
+	tt.True(t, dist <= 10)
+
+	name, dist, err = s.Detect(testScreen(400, 300))
+	tt.Nil(t, err)
+	tt.Equal(t, "desktop", name)
+	tt.Equal(t, 0, dist)
+
+	path := filepath.Join(t.TempDir(), "states.json")
+	tt.Nil(t, s.Save(path))
+
+	s1 := NewStateStore(DHash)
+	tt.Nil(t, s1.Load(path))
+	tt.Equal(t, s.Names(), s1.Names())
+	tt.Equal(t, s.states["dialog"], s1.states["dialog"])
+
+	s.Remove("desktop")
+	tt.Equal(t, []string{"dialog"}, s.Names())
+	_, err = s.Add("bad", 1)
+	tt.NotNil(t, err)
+}
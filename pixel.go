@@ -0,0 +1,153 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"errors"
+	"fmt"
+	"image"
+)
+
+var errNoPoints = errors.New("there are no points")
+
+// PixelSampler read the pixel colors from one capture,
+// all the colors are from the same screen frame
+//
+// Examples:
+//
+//	pts := []robotgo.Point{{10, 10}, {200, 30}, {400, 300}}
+//	ps, err := robotgo.NewPixelSampler(pts)
+//	colors := ps.Colors(pts)
+//
+//	// Capture the region again
+//	err = ps.Sample()
+type PixelSampler struct {
+	region  Rect
+	display int
+	// screen is false for the bitmap sampler
+	screen bool
+	bit    *GoBitmap
+}
+
+// NewPixelSampler capture the bounding rect of the points
+// and return the PixelSampler
+//
+// robotgo.NewPixelSampler(points []Point, displayId int)
+func NewPixelSampler(points []Point, displayId ...int) (*PixelSampler, error) {
+	if len(points) == 0 {
+		return nil, errNoPoints
+	}
+
+	r := image.Rectangle{Min: image.Pt(points[0].X, points[0].Y)}
+	for _, p := range points {
+		r = r.Union(image.Rect(p.X, p.Y, p.X+1, p.Y+1))
+	}
+
+	ps := &PixelSampler{
+		region: Rect{
+			Point: Point{X: r.Min.X, Y: r.Min.Y},
+			Size:  Size{W: r.Dx(), H: r.Dy()},
+		},
+		display: displayIdx(displayId...),
+		screen:  true,
+	}
+	if err := ps.Sample(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// NewBitmapSampler return the PixelSampler of the Bitmap,
+// the origin is the screen position of the bitmap (0, 0)
+// and the screen is not captured
+//
+// robotgo.NewBitmapSampler(bit Bitmap, x, y int)
+func NewBitmapSampler(bit Bitmap, origin ...int) *PixelSampler {
+	ps := &PixelSampler{bit: ToGoBitmap(bit)}
+	if len(origin) > 1 {
+		ps.region.X, ps.region.Y = origin[0], origin[1]
+	}
+	ps.region.W, ps.region.H = bit.Width, bit.Height
+	return ps
+}
+
+// Sample capture the sampler region again,
+// the bitmap sampler is not changed
+func (ps *PixelSampler) Sample() error {
+	if !ps.screen {
+		return nil
+	}
+
+	r := ps.region
+	bit, err := CaptureGoBitmap(r.X, r.Y, r.W, r.H, ps.display)
+	if err != nil {
+		return err
+	}
+	ps.bit = bit
+	return nil
+}
+
+// Region return the sampler screen rect
+func (ps *PixelSampler) Region() Rect {
+	return ps.region
+}
+
+// Hex return the CHex color of the screen position,
+// it returns 0 when the position is out of the region
+func (ps *PixelSampler) Hex(x, y int) CHex {
+	return ps.bit.Hex(x-ps.region.X, y-ps.region.Y)
+}
+
+// Color return the color string of the screen position
+func (ps *PixelSampler) Color(x, y int) string {
+	return fmt.Sprintf("%06x", uint32(ps.Hex(x, y)))
+}
+
+// Hexs return the CHex colors of the points
+func (ps *PixelSampler) Hexs(points []Point) []CHex {
+	arr := make([]CHex, len(points))
+	for i, p := range points {
+		arr[i] = ps.Hex(p.X, p.Y)
+	}
+	return arr
+}
+
+// Colors return the color strings of the points
+func (ps *PixelSampler) Colors(points []Point) []string {
+	arr := make([]string, len(points))
+	for i, p := range points {
+		arr[i] = ps.Color(p.X, p.Y)
+	}
+	return arr
+}
+
+// GetPixelColors get the colors of the points from one capture,
+// it's faster than the GetPixelColor() for many points
+//
+// robotgo.GetPixelColors(points []Point, displayId int)
+//
+// Examples:
+//
+//	colors, err := robotgo.GetPixelColors([]robotgo.Point{{10, 20}, {30, 40}})
+func GetPixelColors(points []Point, displayId ...int) ([]string, error) {
+	ps, err := NewPixelSampler(points, displayId...)
+	if err != nil {
+		return nil, err
+	}
+	return ps.Colors(points), nil
+}
+
+// GetBitmapColors get the colors of the points of the Bitmap,
+// the points are relative to the bitmap
+func GetBitmapColors(bit Bitmap, points []Point) []string {
+	return NewBitmapSampler(bit).Colors(points)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestBitmapSampler(t *testing.T) {
+	b := NewGoBitmap(image.Rect(0, 0, 8, 6))
+	b.Set(1, 2, color.RGBA{0x00, 0xc8, 0x10, 0xff})
+	b.Set(7, 5, color.RGBA{0xab, 0xcd, 0xef, 0xff})
+
+	pts := []Point{{X: 1, Y: 2}, {X: 7, Y: 5}, {X: 8, Y: 0}}
+	tt.Equal(t, []string{"00c810", "abcdef", "000000"},
+		GetBitmapColors(b.Bitmap(), pts))
+
+	ps := NewBitmapSampler(b.Bitmap(), 100, 200)
+	tt.Equal(t, Rect{Point{100, 200}, Size{8, 6}}, ps.Region())
+	tt.Equal(t, CHex(0xabcdef), ps.Hex(107, 205))
+	tt.Equal(t, "00c810", ps.Color(101, 202))
+	tt.Nil(t, ps.Sample())
+	tt.Equal(t, []CHex{0x00c810, 0}, ps.Hexs([]Point{{101, 202}, {1, 2}}))
+
+	_, err := GetPixelColors(nil)
+	tt.Equal(t, errNoPoints, err)
+}
@@ -11,7 +11,16 @@
 
 package robotgo
 
-import ps "github.com/vcaesar/gops"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	ps "github.com/vcaesar/gops"
+)
 
 // Nps process struct
 type Nps struct {
@@ -72,6 +81,45 @@ func Run(path string) ([]byte, error) {
 	return ps.Run(path)
 }
 
+// RunCmd run the command without the shell, write the input to the stdin
+// and return the stdout, the error has the stderr message
+//
+// Examples:
+//
+//	out, err := robotgo.RunCmd([]byte("hello"), "tr", "a-z", "A-Z")
+func RunCmd(input []byte, name string, args ...string) ([]byte, error) {
+	return RunCmdContext(context.Background(), input, name, args...)
+}
+
+// RunCmdContext run the command the same as RunCmd(),
+// the command is killed if the ctx is done
+//
+// Examples:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	out, err := robotgo.RunCmdContext(ctx, nil, "tesseract", "--version")
+func RunCmdContext(ctx context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = &stderr
+	// The children may keep the pipes open after the kill
+	cmd.WaitDelay = time.Second
+
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+	if ctx.Err() != nil {
+		return out, fmt.Errorf("%s: %w", name, ctx.Err())
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return out, fmt.Errorf("%s: %w: %s", name, err, msg)
+	}
+	return out, err
+}
+
 // Kill kill the process by PID
 func Kill(pid int) error {
 	return ps.Kill(pid)
@@ -0,0 +1,92 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"errors"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/randr"
+	"github.com/robotn/xgb/xproto"
+)
+
+// monitorInfo the RandR 1.5 monitor
+type monitorInfo struct {
+	Name    xproto.Atom
+	Primary bool
+	Rect
+	MmWidth, MmHeight int
+	Outputs           []randr.Output
+}
+
+// getMonitors get the active monitors by the RRGetMonitors (RandR 1.5),
+// the xgb randr has not the request, so it is sent raw
+func getMonitors(c *xgb.Conn, root xproto.Window) ([]monitorInfo, error) {
+	buf := make([]byte, 12)
+	c.ExtLock.RLock()
+	buf[0] = c.Extensions["RANDR"]
+	c.ExtLock.RUnlock()
+
+	buf[1] = 42           // RRGetMonitors
+	xgb.Put16(buf[2:], 3) // request size in 4-byte units
+	xgb.Put32(buf[4:], uint32(root))
+	buf[8] = 1 // get_active
+
+	cookie := c.NewCookie(true, true)
+	c.NewRequest(buf, cookie)
+	reply, err := cookie.Reply()
+	if err != nil {
+		return nil, err
+	}
+	return parseMonitors(reply)
+}
+
+// parseMonitors parse the RRGetMonitors reply
+func parseMonitors(buf []byte) ([]monitorInfo, error) {
+	errShort := errors.New("the RRGetMonitors reply is short")
+	if len(buf) < 32 {
+		return nil, errShort
+	}
+
+	n := int(xgb.Get32(buf[12:]))
+	b := 32
+	arr := make([]monitorInfo, 0, n)
+	for i := 0; i < n; i++ {
+		if len(buf) < b+24 {
+			return nil, errShort
+		}
+
+		m := monitorInfo{
+			Name:    xproto.Atom(xgb.Get32(buf[b:])),
+			Primary: buf[b+4] != 0,
+			Rect: Rect{Point{X: int(int16(xgb.Get16(buf[b+8:]))), Y: int(int16(xgb.Get16(buf[b+10:])))},
+				Size{W: int(xgb.Get16(buf[b+12:])), H: int(xgb.Get16(buf[b+14:]))}},
+			MmWidth:  int(xgb.Get32(buf[b+16:])),
+			MmHeight: int(xgb.Get32(buf[b+20:])),
+		}
+		no := int(xgb.Get16(buf[b+6:]))
+		b += 24
+
+		if len(buf) < b+no*4 {
+			return nil, errShort
+		}
+		for j := 0; j < no; j++ {
+			m.Outputs = append(m.Outputs, randr.Output(xgb.Get32(buf[b:])))
+			b += 4
+		}
+		arr = append(arr, m)
+	}
+	return arr, nil
+}
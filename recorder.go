@@ -0,0 +1,395 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	errRecording    = errors.New("the recorder is already started")
+	errNotRecording = errors.New("the recorder is not started")
+	errNoFrames     = errors.New("there are no frames")
+)
+
+// RecordOpts is the Recorder options, the zero value is the default
+type RecordOpts struct {
+	// Region is the screen rect to record, default is the whole screen
+	Region Rect
+	// FPS is the frames per second, default is 10
+	FPS int
+	// Duration is the ring buffer duration (ms), keep the last frames,
+	// default is 10000
+	Duration int
+	// MaxMemory is the frames memory budget (bytes), default is 256 MB
+	MaxMemory int
+	// Cursor draw the mouse cursor into the frames,
+	// the global CaptureCursor is not used by the recorder
+	Cursor bool
+}
+
+// Frame is the recorded frame
+type Frame struct {
+	Img  *image.RGBA
+	Time time.Time
+}
+
+// Recorder is the screen recorder, it captures the region at the FPS
+// on the background goroutine and keeps the recent frames in the ring buffer
+//
+// Examples:
+//
+//	rec := robotgo.NewRecorder(robotgo.RecordOpts{FPS: 5, Duration: 5000})
+//	rec.Start()
+//	defer rec.Stop()
+//
+//	if failed {
+//		rec.SaveGif("failed.gif")
+//	}
+type Recorder struct {
+	opt RecordOpts
+
+	mu     sync.Mutex
+	frames []Frame
+	head   int
+	n      int
+	size   int
+	err    error
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewRecorder create the screen recorder
+func NewRecorder(opts ...RecordOpts) *Recorder {
+	var opt RecordOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.FPS <= 0 {
+		opt.FPS = 10
+	}
+	if opt.Duration <= 0 {
+		opt.Duration = 10000
+	}
+	if opt.MaxMemory <= 0 {
+		opt.MaxMemory = 256 << 20
+	}
+
+	n := max(opt.Duration*opt.FPS/1000, 1)
+	return &Recorder{opt: opt, frames: make([]Frame, n)}
+}
+
+// Start start the recording on the background goroutine
+func (r *Recorder) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stop != nil {
+		return errRecording
+	}
+
+	c, err := NewCapturer()
+	if err != nil {
+		return err
+	}
+	// The capturer draws the cursor, the CaptureCursor is not used
+	c.SetCursor(r.opt.Cursor)
+
+	r.err = nil
+	r.stop, r.done = make(chan struct{}), make(chan struct{})
+	go r.run(c, r.stop, r.done)
+	return nil
+}
+
+// IsRecording return true if the recorder is started
+func (r *Recorder) IsRecording() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stop != nil
+}
+
+// Stop stop the recording, return the last capture error,
+// the frames are kept
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+
+	if stop == nil {
+		return errNotRecording
+	}
+	close(stop)
+	<-done
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) run(c *Capturer, stop, done chan struct{}) {
+	defer close(done)
+	defer c.Close()
+
+	args := []int{}
+	if reg := r.opt.Region; reg.W > 0 && reg.H > 0 {
+		args = []int{reg.X, reg.Y, reg.W, reg.H}
+	}
+
+	tick := time.NewTicker(time.Second / time.Duration(r.opt.FPS))
+	defer tick.Stop()
+	for {
+		img, err := c.CaptureRGBA(args...)
+		if err == nil {
+			r.push(img, time.Now())
+		}
+
+		r.mu.Lock()
+		r.err = err
+		r.mu.Unlock()
+
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// push copy the capturer image into the ring buffer,
+// the snapshot frames are shared, so the dropped frames are not reused
+func (r *Recorder) push(img *image.RGBA, tm time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.n == len(r.frames) {
+		r.drop()
+	}
+
+	// Keep the memory budget
+	size := len(img.Pix)
+	for r.n > 0 && r.size+size > r.opt.MaxMemory {
+		r.drop()
+	}
+	if size > r.opt.MaxMemory {
+		return
+	}
+
+	dst := &image.RGBA{Pix: make([]uint8, size), Stride: img.Stride, Rect: img.Rect}
+	copy(dst.Pix, img.Pix)
+
+	r.frames[(r.head+r.n)%len(r.frames)] = Frame{Img: dst, Time: tm}
+	r.n++
+	r.size += size
+}
+
+// drop drop the oldest frame
+func (r *Recorder) drop() {
+	r.size -= len(r.frames[r.head].Img.Pix)
+	r.frames[r.head] = Frame{}
+	r.head = (r.head + 1) % len(r.frames)
+	r.n--
+}
+
+// Snapshot return the recent frames, from the oldest to the newest
+func (r *Recorder) Snapshot() []Frame {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	arr := make([]Frame, r.n)
+	for i := 0; i < r.n; i++ {
+		arr[i] = r.frames[(r.head+i)%len(r.frames)]
+	}
+	return arr
+}
+
+// SaveGif save the recent frames to the animated gif file
+func (r *Recorder) SaveGif(path string) error {
+	return saveFile(path, func(w io.Writer) error {
+		return EncodeGif(w, r.Snapshot())
+	})
+}
+
+// SaveApng save the recent frames to the animated png file
+func (r *Recorder) SaveApng(path string) error {
+	return saveFile(path, func(w io.Writer) error {
+		return EncodeApng(w, r.Snapshot())
+	})
+}
+
+// SaveFrames save the recent frames to the png files in the dir
+func (r *Recorder) SaveFrames(dir string) error {
+	return SaveFrames(dir, r.Snapshot())
+}
+
+func saveFile(path string, fn func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// frameDelay get the frame display time from the next frame time
+func frameDelay(frames []Frame, i int) time.Duration {
+	if i+1 < len(frames) {
+		return frames[i+1].Time.Sub(frames[i].Time)
+	}
+	if i > 0 {
+		return frames[i].Time.Sub(frames[i-1].Time)
+	}
+	return 100 * time.Millisecond
+}
+
+// EncodeGif encode the frames to the animated gif
+func EncodeGif(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return errNoFrames
+	}
+
+	g := &gif.GIF{}
+	for i, f := range frames {
+		p := image.NewPaletted(f.Img.Rect, palette.Plan9)
+		draw.FloydSteinberg.Draw(p, p.Rect, f.Img, f.Img.Rect.Min)
+
+		g.Image = append(g.Image, p)
+		// The delay is 100ths of a second
+		g.Delay = append(g.Delay, int(frameDelay(frames, i)/(10*time.Millisecond)))
+	}
+	return gif.EncodeAll(w, g)
+}
+
+// EncodeApng encode the frames to the animated png,
+// the frames must be the same size
+func EncodeApng(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return errNoFrames
+	}
+
+	rect := frames[0].Img.Rect
+	buf := bytes.NewBufferString("\x89PNG\r\n\x1a\n")
+	seq := uint32(0)
+	var head []byte
+
+	for i, f := range frames {
+		if f.Img.Rect.Size() != rect.Size() {
+			return errors.New("the frames size is not the same")
+		}
+
+		var b bytes.Buffer
+		if err := png.Encode(&b, f.Img); err != nil {
+			return err
+		}
+		ihdr, idat := pngChunks(b.Bytes())
+		// The png encoder use the RGB for the opaque image
+		if i > 0 && !bytes.Equal(ihdr, head) {
+			return errors.New("the frames color type is not the same")
+		}
+
+		if i == 0 {
+			head = ihdr
+			writeChunk(buf, "IHDR", ihdr)
+			// acTL: the frames number, 0 is the infinite loop
+			writeChunk(buf, "acTL", binary.BigEndian.AppendUint32(
+				binary.BigEndian.AppendUint32(nil, uint32(len(frames))), 0))
+		}
+
+		// fcTL: the frame size, offset, delay (ms), dispose and blend
+		fc := binary.BigEndian.AppendUint32(nil, seq)
+		fc = binary.BigEndian.AppendUint32(fc, uint32(rect.Dx()))
+		fc = binary.BigEndian.AppendUint32(fc, uint32(rect.Dy()))
+		fc = binary.BigEndian.AppendUint32(fc, 0)
+		fc = binary.BigEndian.AppendUint32(fc, 0)
+		fc = binary.BigEndian.AppendUint16(fc, uint16(min(frameDelay(frames, i).Milliseconds(), 0xffff)))
+		fc = binary.BigEndian.AppendUint16(fc, 1000)
+		fc = append(fc, 0, 0)
+		writeChunk(buf, "fcTL", fc)
+		seq++
+
+		for _, d := range idat {
+			if i == 0 {
+				writeChunk(buf, "IDAT", d)
+				continue
+			}
+
+			writeChunk(buf, "fdAT", append(binary.BigEndian.AppendUint32(nil, seq), d...))
+			seq++
+		}
+	}
+
+	writeChunk(buf, "IEND", nil)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pngChunks get the IHDR and IDAT chunks data of the png
+func pngChunks(b []byte) (ihdr []byte, idat [][]byte) {
+	for b = b[8:]; len(b) >= 12; {
+		n := binary.BigEndian.Uint32(b)
+		typ, data := string(b[4:8]), b[8:8+n]
+		switch typ {
+		case "IHDR":
+			ihdr = data
+		case "IDAT":
+			idat = append(idat, data)
+		}
+		b = b[12+n:]
+	}
+	return
+}
+
+func writeChunk(buf *bytes.Buffer, typ string, data []byte) {
+	binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	crc := crc32.NewIEEE()
+	io.WriteString(crc, typ)
+	crc.Write(data)
+
+	buf.WriteString(typ)
+	buf.Write(data)
+	binary.Write(buf, binary.BigEndian, crc.Sum32())
+}
+
+// SaveFrames save the frames to the png files in the dir,
+// the file name is the frame index and the time (ms)
+func SaveFrames(dir string, frames []Frame) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for i, f := range frames {
+		name := fmt.Sprintf("frame_%06d_%d.png", i, f.Time.UnixMilli())
+		if err := SavePng(f.Img, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
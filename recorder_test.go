@@ -0,0 +1,100 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vcaesar/tt"
+)
+
+func testFrames(n int) []Frame {
+	tm := time.Now()
+	var arr []Frame
+	for i := 0; i < n; i++ {
+		img := testScreen(120, 60)
+		testButton(img, i*10, 20)
+		arr = append(arr, Frame{Img: img, Time: tm.Add(time.Duration(i) * 200 * time.Millisecond)})
+	}
+	return arr
+}
+
+func TestRecorderRing(t *testing.T) {
+	r := NewRecorder(RecordOpts{FPS: 10, Duration: 300})
+	tt.Equal(t, 3, len(r.frames))
+
+	frames := testFrames(5)
+	for _, f := range frames {
+		r.push(f.Img, f.Time)
+	}
+	arr := r.Snapshot()
+	tt.Equal(t, 3, len(arr))
+	tt.Equal(t, frames[2].Time, arr[0].Time)
+	tt.Equal(t, frames[4].Img.Pix, arr[2].Img.Pix)
+	tt.Equal(t, 3*len(frames[0].Img.Pix), r.size)
+
+	// The memory budget
+	r = NewRecorder(RecordOpts{MaxMemory: 2*len(frames[0].Img.Pix) + 1})
+	for _, f := range frames {
+		r.push(f.Img, f.Time)
+	}
+	tt.Equal(t, 2, len(r.Snapshot()))
+
+	tt.Equal(t, errNotRecording, r.Stop())
+	tt.False(t, r.IsRecording())
+}
+
+func TestEncodeGif(t *testing.T) {
+	var b bytes.Buffer
+	tt.Nil(t, EncodeGif(&b, testFrames(3)))
+
+	g, err := gif.DecodeAll(&b)
+	tt.Nil(t, err)
+	tt.Equal(t, 3, len(g.Image))
+	tt.Equal(t, 20, g.Delay[0])
+
+	tt.Equal(t, errNoFrames, EncodeGif(&b, nil))
+}
+
+func TestEncodeApng(t *testing.T) {
+	var b bytes.Buffer
+	frames := testFrames(3)
+	tt.Nil(t, EncodeApng(&b, frames))
+
+	// The png decoder read the first frame
+	img, err := png.Decode(bytes.NewReader(b.Bytes()))
+	tt.Nil(t, err)
+	tt.Equal(t, frames[0].Img.At(5, 25), img.At(5, 25))
+
+	data := b.Bytes()
+	tt.Equal(t, 1, bytes.Count(data, []byte("acTL")))
+	tt.Equal(t, 3, bytes.Count(data, []byte("fcTL")))
+	tt.True(t, bytes.Count(data, []byte("fdAT")) >= 2)
+
+	frames[1].Img = image.NewRGBA(image.Rect(0, 0, 10, 10))
+	tt.NotNil(t, EncodeApng(&b, frames))
+}
+
+func TestSaveFrames(t *testing.T) {
+	dir := t.TempDir()
+	tt.Nil(t, SaveFrames(dir, testFrames(2)))
+
+	arr, err := os.ReadDir(dir)
+	tt.Nil(t, err)
+	tt.Equal(t, 2, len(arr))
+}
@@ -54,6 +54,8 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
+	"math/rand"
 	"runtime"
 	"syscall"
 	"time"
@@ -78,13 +80,21 @@ var (
 	// KeySleep set the key default millisecond sleep time
 	KeySleep = 10
 
-	// DisplayID set the screen display id
+	// DisplayID set the screen display id,
+	// it's the Displays() index on Linux
 	DisplayID = -1
 
 	// NotPid used the hwnd not pid in windows
 	NotPid bool
 	// Scale option the os screen scale
 	Scale bool
+	// CaptureCursor draw the mouse cursor into the screen capture
+	CaptureCursor bool
+
+	// BlockTimeout set the BlockInput auto release millisecond time
+	BlockTimeout = 30000
+	// BlockHotkey set the BlockInput safety unlock key and modifiers
+	BlockHotkey = []string{"esc", "ctrl", "alt"}
 )
 
 type (
@@ -129,6 +139,15 @@ type Rect struct {
 	Size
 }
 
+// PointerControl is the pointer acceleration settings,
+// the pointer moves AccelNum / AccelDenom times faster
+// when it moves more than Threshold pixels at once
+type PointerControl struct {
+	AccelNum   int
+	AccelDenom int
+	Threshold  int
+}
+
 // Try handler(err)
 func Try(fun func(), handler func(interface{})) {
 	defer func() {
@@ -236,7 +255,7 @@ func GetLocationColor(displayId ...int) string {
 	return GetPixelColor(x, y, displayId...)
 }
 
-// IsMain is main display
+// IsMain is main display, it's the RandR primary monitor on Linux
 func IsMain(displayId int) bool {
 	return displayId == GetMainId()
 }
@@ -291,11 +310,13 @@ func GetScreenSize() (int, int) {
 	return int(size.w), int(size.h)
 }
 
-// GetScreenRect get the screen rect (x, y, w, h)
+// GetScreenRect get the screen rect (x, y, w, h),
+// the displayId is the Displays() index on Linux,
+// default is the DisplayID or the whole screen
 func GetScreenRect(displayId ...int) Rect {
-	display := -1
-	if len(displayId) > 0 {
-		display = displayId[0]
+	display := displayIdx(displayId...)
+	if r, ok := displayRect(display); ok {
+		return r
 	}
 
 	rect := C.getScreenRect(C.int32_t(display))
@@ -321,10 +342,21 @@ func GetScaleSize(displayId ...int) (int, int) {
 }
 
 // CaptureScreen capture the screen return bitmap(c struct),
-// use `defer robotgo.FreeBitmap(bitmap)` to free the bitmap
+// use `defer robotgo.FreeBitmap(bitmap)` to free the bitmap,
+// set the `robotgo.CaptureCursor = true` to draw the mouse cursor
 //
 // robotgo.CaptureScreen(x, y, w, h int)
 func CaptureScreen(args ...int) CBitmap {
+	bit, x, y := captureScreen(args...)
+	if CaptureCursor && bit != nil {
+		drawCursorBitmap(ToBitmap(bit), x, y)
+	}
+	return bit
+}
+
+// captureScreen capture the screen without the cursor,
+// return the bitmap and the origin of it on the screen
+func captureScreen(args ...int) (CBitmap, int, int) {
 	var x, y, w, h C.int32_t
 	displayId := -1
 	if DisplayID != -1 {
@@ -343,7 +375,7 @@ func CaptureScreen(args ...int) CBitmap {
 	} else {
 		// Get the main screen rect.
 		rect := GetScreenRect(displayId)
-		if runtime.GOOS == "windows" {
+		if runtime.GOOS != "darwin" {
 			x = C.int32_t(rect.X)
 			y = C.int32_t(rect.Y)
 		}
@@ -358,22 +390,25 @@ func CaptureScreen(args ...int) CBitmap {
 	}
 
 	bit := C.capture_screen(x, y, w, h, C.int32_t(displayId), C.int8_t(isPid))
-	return CBitmap(bit)
+	return CBitmap(bit), int(x), int(y)
 }
 
-// CaptureGo capture the screen and return bitmap(go struct)
+// CaptureGo capture the screen and return bitmap(go struct),
+// the pixels are copied to the Go memory, no need to free
 func CaptureGo(args ...int) Bitmap {
-	bit := CaptureScreen(args...)
-	defer FreeBitmap(bit)
+	bit, err := CaptureGoBitmap(args...)
+	if err != nil {
+		return Bitmap{}
+	}
 
-	return ToBitmap(bit)
+	return bit.Bitmap()
 }
 
 // CaptureImg capture the screen and return image.Image, error
 func CaptureImg(args ...int) (image.Image, error) {
 	bit := CaptureScreen(args...)
 	if bit == nil {
-		return nil, errors.New("Capture image not found.")
+		return nil, errCapture
 	}
 	defer FreeBitmap(bit)
 
@@ -398,7 +433,8 @@ func ToMMBitmapRef(bit CBitmap) C.MMBitmapRef {
 	return C.MMBitmapRef(bit)
 }
 
-// ToBitmap trans C.MMBitmapRef to Bitmap
+// ToBitmap trans C.MMBitmapRef to Bitmap,
+// the Bitmap shares the C memory, it's valid until the FreeBitmap(bit)
 func ToBitmap(bit CBitmap) Bitmap {
 	bitmap := Bitmap{
 		ImgBuf:        (*uint8)(bit.imageBuffer),
@@ -412,10 +448,18 @@ func ToBitmap(bit CBitmap) Bitmap {
 	return bitmap
 }
 
-// ToCBitmap trans Bitmap to C.MMBitmapRef
+// ToCBitmap trans Bitmap to C.MMBitmapRef,
+// the pixels are copied to the C memory, the caller owns the CBitmap
+// and should call the FreeBitmap() or use the NewCBitmapRef()
 func ToCBitmap(bit Bitmap) CBitmap {
+	size := bit.Bytewidth * bit.Height
+	buf := C.malloc(C.size_t(max(size, 1)))
+	if size > 0 && bit.ImgBuf != nil {
+		copy(unsafe.Slice((*uint8)(buf), size), unsafe.Slice(bit.ImgBuf, size))
+	}
+
 	cbitmap := C.createMMBitmap_c(
-		(*C.uint8_t)(bit.ImgBuf),
+		(*C.uint8_t)(buf),
 		C.int32_t(bit.Width),
 		C.int32_t(bit.Height),
 		C.int32_t(bit.Bytewidth),
@@ -535,6 +579,20 @@ func MoveScale(x, y int, displayId ...int) (int, int) {
 	return x, y
 }
 
+// ScreenToMove convert the screen capture pixels to the Move() x, y,
+// it's the reverse of the MoveScale(), use it for the FindBitmap()
+// and the OCR boxes with the scale
+func ScreenToMove(x, y int) (int, int) {
+	if Scale || runtime.GOOS == "windows" {
+		// Ceil it, the MoveScale() truncates back to the same pixel
+		f := ScaleF()
+		x = int(math.Ceil(float64(x) * f))
+		y = int(math.Ceil(float64(y) * f))
+	}
+
+	return x, y
+}
+
 // Move move the mouse to (x, y)
 //
 // Examples:
@@ -542,13 +600,18 @@ func MoveScale(x, y int, displayId ...int) (int, int) {
 //	robotgo.MouseSleep = 100  // 100 millisecond
 //	robotgo.Move(10, 10)
 func Move(x, y int, displayId ...int) {
-	x, y = MoveScale(x, y, displayId...)
+	mx, my := confineClamp(MoveScale(x, y, displayId...))
 
-	cx := C.int32_t(x)
-	cy := C.int32_t(y)
-	C.moveMouse(C.MMPointInt32Make(cx, cy))
+	cx := C.int32_t(mx)
+	cy := C.int32_t(my)
+	passInput(func() {
+		C.moveMouse(C.MMPointInt32Make(cx, cy))
+	})
 
 	MilliSleep(MouseSleep)
+	debugFrame("Move", nil, Point{}, func(a *Annotator) {
+		a.Cross(x, y, annotPoint, fmt.Sprintf("Move %d, %d", x, y))
+	})
 }
 
 // Deprecated: use the DragSmooth(),
@@ -566,7 +629,9 @@ func Drag(x, y int, args ...string) {
 		button = CheckMouse(args[0])
 	}
 
-	C.dragMouse(C.MMPointInt32Make(cx, cy), button)
+	passInput(func() {
+		C.dragMouse(C.MMPointInt32Make(cx, cy), button)
+	})
 	MilliSleep(MouseSleep)
 }
 
@@ -584,6 +649,8 @@ func DragSmooth(x, y int, args ...interface{}) {
 
 // MoveSmooth move the mouse smooth,
 // moves mouse to x, y human like, with the mouse button up.
+// The target is clamped to the ConfinePointer() rect if it's set,
+// return false if the path doesn't reach the target.
 //
 // robotgo.MoveSmooth(x, y int, low, high float64, mouseDelay int)
 //
@@ -596,15 +663,12 @@ func MoveSmooth(x, y int, args ...interface{}) bool {
 	// 	f := ScaleF()
 	// 	x, y = Scaled0(x, f), Scaled0(y, f)
 	// }
-	x, y = MoveScale(x, y)
-
-	cx := C.int32_t(x)
-	cy := C.int32_t(y)
+	mx, my := confineClamp(MoveScale(x, y))
 
 	var (
 		mouseDelay = 1
-		low        C.double
-		high       C.double
+		low        = 1.0
+		high       = 3.0
 	)
 
 	if len(args) > 2 {
@@ -612,17 +676,69 @@ func MoveSmooth(x, y int, args ...interface{}) bool {
 	}
 
 	if len(args) > 1 {
-		low = C.double(args[0].(float64))
-		high = C.double(args[1].(float64))
-	} else {
-		low = 1.0
-		high = 3.0
+		low = args[0].(float64)
+		high = args[1].(float64)
 	}
 
-	cbool := C.smoothlyMoveMouse(C.MMPointInt32Make(cx, cy), low, high)
+	// The path is moved in Go, so the BlockInput grab is released
+	// around each motion event, and the path is drawn in the debug mode
+	var path []Point
+	debug := IsDebug()
+
+	pos := C.location()
+	ok := smoothMove(int(pos.x), int(pos.y), mx, my, func(px, py int) {
+		passInput(func() {
+			C.moveMouse(C.MMPointInt32Make(C.int32_t(px), C.int32_t(py)))
+		})
+		if debug {
+			path = append(path, Point{X: px, Y: py})
+		}
+		C.microsleep(C.double(low + rand.Float64()*(high-low)))
+	})
 	MilliSleep(MouseSleep + mouseDelay)
 
-	return bool(cbool)
+	debugFrame("MoveSmooth", nil, Point{}, func(a *Annotator) {
+		if Scale || runtime.GOOS == "windows" {
+			f := ScaleF()
+			for i := range path {
+				path[i] = Point{X: Scaled0(path[i].X, f), Y: Scaled0(path[i].Y, f)}
+			}
+		}
+
+		a.Path(path, annotPath)
+		a.Cross(x, y, annotPoint, fmt.Sprintf("MoveSmooth %d, %d", x, y))
+	})
+	return ok
+}
+
+// smoothMove move the human like mouse path to the (ex, ey) by the fn,
+// the random gravity pulls the unit velocity to the end, the same as
+// the C smoothlyMoveMouse() of the old versions, the path stops within
+// one pixel of the end, return false if the steps limit is reached
+func smoothMove(x, y, ex, ey int, fn func(x, y int)) bool {
+	var vx, vy float64
+
+	d := math.Hypot(float64(ex-x), float64(ey-y))
+	// The velocity may circle the end, limit the steps
+	for n := 10*int(d) + 100; d > 1.0; n-- {
+		if n <= 0 {
+			return false
+		}
+
+		gravity := 5 + rand.Float64()*495
+		vx += gravity * float64(ex-x) / d
+		vy += gravity * float64(ey-y) / d
+
+		// Normalize the velocity to the unit vector
+		v := math.Hypot(vx, vy)
+		vx, vy = vx/v, vy/v
+
+		x += int(math.Floor(vx + 0.5))
+		y += int(math.Floor(vy + 0.5))
+		fn(x, y)
+		d = math.Hypot(float64(ex-x), float64(ey-y))
+	}
+	return true
 }
 
 // MoveArgs get the mouse relative args
@@ -645,6 +761,98 @@ func MoveSmoothRelative(x, y int, args ...interface{}) {
 	MoveSmooth(mx, my, args...)
 }
 
+// MoveDelta move the mouse with the relative motion (dx, dy),
+// not warp the pointer like the MoveRelative(), it work with
+// the apps locked the pointer (games, 3D viewports, remote desktops).
+//
+// The X11 pointer acceleration may scale the deltas, see the IsRelativeAccel()
+//
+// Examples:
+//
+//	robotgo.MoveDelta(10, -10)
+func MoveDelta(dx, dy int) {
+	passInput(func() {
+		C.moveMouseRelative(C.int32_t(dx), C.int32_t(dy))
+	})
+	MilliSleep(MouseSleep)
+}
+
+// MoveDeltaSmooth move the mouse smooth with the relative motion,
+// split the (dx, dy) into small steps, the step is kept under
+// the pointer acceleration threshold if it's possible.
+//
+// robotgo.MoveDeltaSmooth(dx, dy int, low, high float64, mouseDelay int)
+//
+// Examples:
+//
+//	robotgo.MoveDeltaSmooth(100, 50)
+//	robotgo.MoveDeltaSmooth(100, 50, 1.0, 2.0)
+func MoveDeltaSmooth(dx, dy int, args ...interface{}) {
+	var (
+		mouseDelay = 1
+		low        = 1.0
+		high       = 3.0
+	)
+
+	if len(args) > 2 {
+		mouseDelay = args[2].(int)
+	}
+	if len(args) > 1 {
+		low = args[0].(float64)
+		high = args[1].(float64)
+	}
+
+	n := deltaSteps(dx, dy)
+	var px, py int
+	for i := 1; i <= n; i++ {
+		x, y := dx*i/n, dy*i/n
+		passInput(func() {
+			C.moveMouseRelative(C.int32_t(x-px), C.int32_t(y-py))
+		})
+		px, py = x, y
+
+		C.microsleep(C.double(low + rand.Float64()*(high-low)))
+	}
+
+	MilliSleep(MouseSleep + mouseDelay)
+}
+
+// deltaSteps get the steps count of the MoveDeltaSmooth
+func deltaSteps(dx, dy int) int {
+	step := 10
+	pc, err := GetPointerControl()
+	if err == nil && pc.Threshold > 1 && pc.Threshold <= step {
+		// |dx| + |dy| of each step less than the threshold
+		step = pc.Threshold - 1
+	}
+
+	return stepsUnder(abs(dx), abs(dy), step)
+}
+
+// stepsUnder get the steps count that the |dx| + |dy| of each step
+// is not greater than the step, the rounded steps are counted too
+func stepsUnder(dx, dy, step int) int {
+	n := max((dx+dy+step-1)/step, 1)
+	// The step is rounded up on both axes, n = max(dx, dy) is the unit steps
+	for n < max(dx, dy) && (dx+n-1)/n+(dy+n-1)/n > step {
+		n++
+	}
+	return n
+}
+
+// sysRelativeAccel return true if the system scales the relative motion,
+// the Windows "Enhance pointer precision" or the pointer speed
+func sysRelativeAccel() bool {
+	return C.getPointerAccel() != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
 // Location get the mouse location position return x, y
 func Location() (int, int) {
 	pos := C.location()
@@ -682,12 +890,7 @@ func ClickV1(args ...interface{}) {
 		double = args[1].(bool)
 	}
 
-	if !double {
-		C.clickMouse(button)
-	} else {
-		C.doubleClick(button, 2)
-	}
-
+	clickBtn(button, double, 1)
 	MilliSleep(MouseSleep)
 }
 
@@ -704,10 +907,13 @@ func Click(args ...interface{}) error {
 		button C.MMMouseButton = C.LEFT_BUTTON
 		double bool
 		count  int
+
+		btn = "left"
 	)
 
 	if len(args) > 0 {
-		btn, ok := args[0].(string)
+		var ok bool
+		btn, ok = args[0].(string)
 		if !ok {
 			return errors.New("first argument must be a button string")
 		}
@@ -725,24 +931,90 @@ func Click(args ...interface{}) error {
 		count = args[2].(int)
 	}
 
-	defer MilliSleep(MouseSleep)
-	if !double {
-		if code := C.toggleMouse(true, button); code != 0 {
-			return formatClickError(int(code), button, "down", count)
+	err := clickBtn(button, double, count)
+	MilliSleep(MouseSleep)
+
+	debugFrame("Click", nil, Point{}, func(a *Annotator) {
+		x, y := Location()
+		label := "Click " + btn
+		if double {
+			label += " double"
 		}
-		MilliSleep(5)
-		if code := C.toggleMouse(false, button); code != 0 {
-			return formatClickError(int(code), button, "up", count)
+		if err != nil {
+			label += ": " + err.Error()
 		}
-	} else {
-		if code := C.doubleClick(button, 2); code != 0 {
+		a.Cross(x, y, annotPoint, label)
+	})
+	return err
+}
+
+func clickBtn(button C.MMMouseButton, double bool, count int) error {
+	if !double {
+		return clickOnce(button, count)
+	}
+
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		var code C.int
+		passInput(func() {
+			code = C.doubleClick(button, 2)
+		})
+		if code != 0 {
 			return formatClickError(int(code), button, "double", 2)
 		}
+		return nil
+	}
+
+	if err := clickOnce(button, 1); err != nil {
+		return err
+	}
+	MilliSleep(clickGap())
+	return clickOnce(button, 2)
+}
+
+func clickOnce(button C.MMMouseButton, count int) error {
+	if code := toggleMouse(true, button); code != 0 {
+		return formatClickError(int(code), button, "down", count)
+	}
+	MilliSleep(5)
+	if code := toggleMouse(false, button); code != 0 {
+		return formatClickError(int(code), button, "up", count)
 	}
 	return nil
 }
 
-// MultiClick performs multiple clicks and returns error
+// toggleMouse send the single mouse button event
+func toggleMouse(down C.bool, button C.MMMouseButton) (code C.int) {
+	passInput(func() {
+		code = C.toggleMouse(down, button)
+	})
+	return
+}
+
+// clickGap get the sleep time between the multi clicks,
+// keep it well within the system double click time
+func clickGap() int {
+	gap := DoubleClickTime() / 4
+	if gap > 100 {
+		gap = 100
+	}
+	return gap
+}
+
+// defaultDoubleClickTime is the double click time (millisecond)
+// if the system one is not found
+const defaultDoubleClickTime = 500
+
+// DoubleClickTime get the system double click time (millisecond),
+// read from the XSETTINGS or the X resources on Linux
+func DoubleClickTime() int {
+	if tm := int(C.getDoubleClickTime()); tm > 0 {
+		return tm
+	}
+	return xDoubleClickTime()
+}
+
+// MultiClick performs multiple clicks and returns error,
+// the clicks interval is taken from the DoubleClickTime()
 //
 // robotgo.MultiClick(button string, count int)
 func MultiClick(button string, count int, click ...bool) error {
@@ -753,12 +1025,21 @@ func MultiClick(button string, count int, click ...bool) error {
 
 	if runtime.GOOS == "darwin" && len(click) <= 0 {
 		btn := CheckMouse(button)
-		code := C.doubleClick(btn, C.int(count))
+		var code C.int
+		passInput(func() {
+			code = C.doubleClick(btn, C.int(count))
+		})
 		return formatClickError(int(code), btn, "down", count)
 	}
 
+	btn := CheckMouse(button)
+	gap := clickGap()
+
 	for i := 0; i < count; i++ {
-		if err := Click(button, false, i+1); err != nil {
+		if i > 0 {
+			MilliSleep(gap)
+		}
+		if err := clickOnce(btn, i+1); err != nil {
 			return err
 		}
 	}
@@ -849,7 +1130,7 @@ func Toggle(key ...interface{}) error {
 		down = false
 	}
 
-	code := C.toggleMouse(C.bool(down), button)
+	code := toggleMouse(C.bool(down), button)
 	if len(key) > 2 {
 		MilliSleep(MouseSleep)
 	}
@@ -885,7 +1166,9 @@ func Scroll(x, y int, args ...int) {
 	cx := C.int(x)
 	cy := C.int(y)
 
-	C.scrollMouseXY(cx, cy)
+	passInput(func() {
+		C.scrollMouseXY(cx, cy)
+	})
 	MilliSleep(MouseSleep + msDelay)
 }
 
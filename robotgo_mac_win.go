@@ -14,6 +14,12 @@
 
 package robotgo
 
+import (
+	"image"
+
+	"github.com/vcaesar/screenshot"
+)
+
 // GetBounds get the window bounds
 func GetBounds(pid int, args ...int) (int, int, int, int) {
 	var isPid int
@@ -68,6 +74,28 @@ func DisplaysNum() int {
 	return getNumDisplays()
 }
 
+// Displays get the monitors, the ID is the index, the primary
+// is the monitor at (0, 0), the Name, Rotation and Refresh are
+// only supported on Linux
+func Displays() ([]DisplayInfo, error) {
+	n := screenshot.NumActiveDisplays()
+	scale := ScaleF()
+
+	arr := make([]DisplayInfo, n)
+	for i := range arr {
+		b := screenshot.GetDisplayBounds(i)
+		arr[i] = DisplayInfo{ID: i, Primary: b.Min == image.Point{},
+			Rect:  Rect{Point{X: b.Min.X, Y: b.Min.Y}, Size{W: b.Dx(), H: b.Dy()}},
+			Scale: scale}
+	}
+	return arr, nil
+}
+
+// displayRect the display id is not the index on the macOS and Windows
+func displayRect(display int) (Rect, bool) {
+	return Rect{}, false
+}
+
 // Alert show a alert window
 // Displays alert with the attributes.
 // If cancel button is not given, only the default button is displayed
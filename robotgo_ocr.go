@@ -15,24 +15,130 @@
 package robotgo
 
 import (
+	"errors"
+	"image"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/otiai10/gosseract/v2"
 )
 
 // GetText get the image text by tesseract ocr
 func GetText(imgPath string, args ...string) (string, error) {
-	var lang = "eng"
-
-	if len(args) > 0 {
-		lang = args[0]
-		if lang == "zh" {
-			lang = "chi_sim"
-		}
-	}
-
 	client := gosseract.NewClient()
 	defer client.Close()
 
 	client.SetImage(imgPath)
-	client.SetLanguage(lang)
+	client.SetLanguage(ocrLang(args))
 	return client.Text()
 }
+
+// gosseractOCR is the OCR of the gosseract client, the tesseract api
+// is not goroutine safe, the recognize is serialized by the mutex
+type gosseractOCR struct {
+	mu     sync.Mutex
+	opt    OCROpts
+	client *gosseract.Client
+}
+
+// NewTesseract create the tesseract OCR of the options,
+// it's the gosseract (libtesseract) of the `ocr` tag build
+func NewTesseract(opts ...OCROpts) OCR {
+	var opt OCROpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt.Lang = ocrLang([]string{opt.Lang})
+
+	return &gosseractOCR{opt: opt}
+}
+
+// init create the client once, the tesseract is initialized
+// by the first image
+func (c *gosseractOCR) init() error {
+	if c.client != nil {
+		return nil
+	}
+
+	opt := c.opt
+	// The gosseract initializes the tesseract with the default engine mode
+	if opt.EngineMode > 0 {
+		return errors.New("the EngineMode is not supported by the gosseract, use the NewTesseractCLI()")
+	}
+
+	client := gosseract.NewClient()
+	if err := client.SetLanguage(strings.Split(opt.Lang, "+")...); err != nil {
+		client.Close()
+		return err
+	}
+	if opt.TessdataPrefix != "" {
+		client.SetTessdataPrefix(opt.TessdataPrefix)
+	}
+	if opt.PageSegMode > 0 {
+		client.SetPageSegMode(gosseract.PageSegMode(opt.PageSegMode))
+	}
+	if opt.Whitelist != "" {
+		client.SetWhitelist(opt.Whitelist)
+	}
+	if opt.Blacklist != "" {
+		client.SetBlacklist(opt.Blacklist)
+	}
+	if opt.DPI > 0 {
+		client.SetVariable("user_defined_dpi", strconv.Itoa(opt.DPI))
+	}
+
+	c.client = client
+	return nil
+}
+
+// Recognize recognize the words by the gosseract bounding boxes
+func (c *gosseractOCR) Recognize(img image.Image) ([]TextBox, error) {
+	b, err := encodeOCR(img)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	if err := c.client.SetImageFromBytes(b); err != nil {
+		return nil, err
+	}
+
+	boxes, err := c.client.GetBoundingBoxesVerbose()
+	if err != nil {
+		return nil, err
+	}
+
+	var arr []TextBox
+	for _, box := range boxes {
+		if box.Word == "" {
+			continue
+		}
+
+		r := box.Box
+		arr = append(arr, TextBox{
+			Rect:  Rect{Point{X: r.Min.X, Y: r.Min.Y}, Size{W: r.Dx(), H: r.Dy()}},
+			Text:  box.Word,
+			Conf:  box.Confidence,
+			Block: box.BlockNum, Par: box.ParNum, Line: box.LineNum,
+		})
+	}
+	return arr, nil
+}
+
+// Close close the gosseract client
+func (c *gosseractOCR) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client == nil {
+		return nil
+	}
+	err := c.client.Close()
+	c.client = nil
+	return err
+}
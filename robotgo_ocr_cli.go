@@ -0,0 +1,32 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !ocr
+// +build !ocr
+
+package robotgo
+
+// GetText get the image text by tesseract ocr
+//
+// robotgo.GetText(imgPath, lang string)
+func GetText(imgPath string, args ...string) (string, error) {
+	body, err := RunCmd(nil, "tesseract", imgPath, "stdout", "-l", ocrLang(args))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// NewTesseract create the tesseract OCR of the options, it's the
+// TesseractCLI, build with the `ocr` tag to use the gosseract (libtesseract)
+func NewTesseract(opts ...OCROpts) OCR {
+	return NewTesseractCLI(opts...)
+}
@@ -17,8 +17,11 @@ package robotgo
 import (
 	"errors"
 	"log"
+	"strconv"
+	"sync"
 
 	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/randr"
 	"github.com/robotn/xgb/xinerama"
 	"github.com/robotn/xgb/xproto"
 	"github.com/robotn/xgbutil"
@@ -27,6 +30,28 @@ import (
 
 var xu *xgbutil.XUtil
 
+var (
+	xc     *xgb.Conn
+	xcLock sync.Mutex
+)
+
+// getXConn get the shared xgb connection, open it on the first call
+func getXConn() (*xgb.Conn, error) {
+	xcLock.Lock()
+	defer xcLock.Unlock()
+
+	if xc != nil {
+		return xc, nil
+	}
+
+	c, err := xgb.NewConn()
+	if err != nil {
+		return nil, err
+	}
+	xc = c
+	return xc, nil
+}
+
 // GetBounds get the window bounds
 func GetBounds(pid int, args ...int) (int, int, int, int) {
 	var isPid int
@@ -177,44 +202,390 @@ func GetXidByPid(xu *xgbutil.XUtil, pid int) (xproto.Window, error) {
 	return 0, errors.New("failed to find a window with a matching pid.")
 }
 
-// DisplaysNum get the count of displays
-func DisplaysNum() int {
+var (
+	randrOnce     sync.Once
+	randrErr      error
+	randrMonitors bool
+
+	dispOnce  sync.Once
+	dispLock  sync.Mutex
+	dispCache []DisplayInfo
+	dispWatch bool
+	dispGen   int
+)
+
+// initRandr init the RandR extension of the connection,
+// the RandR 1.3 is needed to get the outputs,
+// monitors is the RandR 1.5 GetMonitors is supported
+func initRandr(c *xgb.Conn) (monitors bool, err error) {
+	if err := randr.Init(c); err != nil {
+		return false, err
+	}
+
+	v, err := randr.QueryVersion(c, 1, 5).Reply()
+	if err != nil {
+		return false, err
+	}
+	if v.MajorVersion < 1 || v.MajorVersion == 1 && v.MinorVersion < 3 {
+		return false, errors.New("the RandR 1.3 is not supported")
+	}
+	return v.MajorVersion > 1 || v.MinorVersion >= 5, nil
+}
+
+// Displays get the monitors by the RandR, the ID is the index
+// in the RandR order, the disabled outputs are skipped and
+// the mirrored outputs are one monitor,
+// it falls back to the Xinerama screens without the RandR 1.3,
+// the result is cached until the RandR screen changes
+//
+// Examples:
+//
+//	arr, err := robotgo.Displays()
+//	for _, d := range arr {
+//		fmt.Println(d.ID, d.Name, d.Primary, d.Rect, d.Refresh, d.DPI)
+//	}
+func Displays() ([]DisplayInfo, error) {
+	dispOnce.Do(watchDisplays)
+
+	dispLock.Lock()
+	if dispCache != nil {
+		arr := append([]DisplayInfo(nil), dispCache...)
+		dispLock.Unlock()
+		return arr, nil
+	}
+	gen := dispGen
+	dispLock.Unlock()
+
+	arr, err := loadDisplays()
+	if err != nil {
+		return nil, err
+	}
+
+	dispLock.Lock()
+	// Not cache it if the screen is changed while loading
+	if dispWatch && gen == dispGen {
+		dispCache = arr
+	}
+	dispLock.Unlock()
+	return append([]DisplayInfo(nil), arr...), nil
+}
+
+// loadDisplays get the displays from the X server
+func loadDisplays() ([]DisplayInfo, error) {
+	c, err := getXConn()
+	if err != nil {
+		return nil, err
+	}
+	randrOnce.Do(func() {
+		randrMonitors, randrErr = initRandr(c)
+	})
+
+	var arr []DisplayInfo
+	if randrErr == nil {
+		arr, err = randrDisplays(c, randrMonitors)
+	}
+	if randrErr != nil || err != nil || len(arr) == 0 {
+		arr, err = xineramaDisplays(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The X11 desktop publishes one scale for all the monitors
+	scale := desktopScale(xSettings(c), xResources(c))
+	for i := range arr {
+		arr[i].ID = i
+		arr[i].DPI = displayDPI(arr[i])
+		arr[i].Scale = scale
+	}
+	return arr, nil
+}
+
+// desktopScale get the scale published by the desktop, the XSETTINGS
+// "Xft/DPI" (1024 * dpi) or the X resources "Xft.dpi", 96 dpi is 1,
+// return 1 if it's not set
+func desktopScale(settings []byte, res string) float64 {
+	if dpi, ok := parseXSettingsInt(settings, "Xft/DPI"); ok && dpi > 0 {
+		return float64(dpi) / 1024 / 96
+	}
+
+	if v, ok := parseXResource(res, "Xft.dpi"); ok {
+		if dpi, err := strconv.ParseFloat(v, 64); err == nil && dpi > 0 {
+			return dpi / 96
+		}
+	}
+	return 1
+}
+
+// clearDisplays clear the displays cache
+func clearDisplays() {
+	dispLock.Lock()
+	dispCache = nil
+	dispGen++
+	dispLock.Unlock()
+}
+
+// watchDisplays clear the displays cache on the RandR screen, crtc and
+// output changes, it uses its own connection to not take the events
+// of the shared one, the displays are not cached without it
+func watchDisplays() {
 	c, err := xgb.NewConn()
 	if err != nil {
-		return 0
+		return
+	}
+	if _, err := initRandr(c); err != nil {
+		c.Close()
+		return
 	}
-	defer c.Close()
 
-	err = xinerama.Init(c)
+	root := xproto.Setup(c).DefaultScreen(c).Root
+	mask := randr.NotifyMaskScreenChange | randr.NotifyMaskCrtcChange |
+		randr.NotifyMaskOutputChange
+	if err := randr.SelectInputChecked(c, root, uint16(mask)).Check(); err != nil {
+		c.Close()
+		return
+	}
+
+	dispLock.Lock()
+	dispWatch = true
+	dispLock.Unlock()
+
+	go func() {
+		for {
+			ev, xerr := c.WaitForEvent()
+			if ev == nil && xerr == nil {
+				break
+			}
+			if ev != nil {
+				clearDisplays()
+			}
+		}
+
+		// The connection is closed
+		dispLock.Lock()
+		dispWatch = false
+		dispCache = nil
+		dispGen++
+		dispLock.Unlock()
+	}()
+}
+
+// randrDisplays get the monitors by the RandR 1.5 GetMonitors,
+// or the connected outputs with the crtc
+func randrDisplays(c *xgb.Conn, monitors bool) ([]DisplayInfo, error) {
+	root := xproto.Setup(c).DefaultScreen(c).Root
+	res, err := randr.GetScreenResourcesCurrent(c, root).Reply()
 	if err != nil {
+		return nil, err
+	}
+
+	modes := make(map[randr.Mode]randr.ModeInfo, len(res.Modes))
+	for _, m := range res.Modes {
+		modes[randr.Mode(m.Id)] = m
+	}
+
+	if monitors {
+		arr, err := monitorDisplays(c, root, res.ConfigTimestamp, modes)
+		if err == nil && len(arr) > 0 {
+			return dedupeDisplays(arr), nil
+		}
+	}
+
+	primary, err := randr.GetOutputPrimary(c, root).Reply()
+	if err != nil {
+		return nil, err
+	}
+
+	var arr []DisplayInfo
+	for _, o := range res.Outputs {
+		out, crtc, err := outputCrtc(c, o, res.ConfigTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		if crtc == nil {
+			continue
+		}
+
+		d := DisplayInfo{
+			Name:    string(out.Name),
+			Primary: o == primary.Output,
+			Rect: Rect{Point{X: int(crtc.X), Y: int(crtc.Y)},
+				Size{W: int(crtc.Width), H: int(crtc.Height)}},
+			Rotation: rotationDeg(crtc.Rotation),
+			Refresh:  modeRefresh(modes[crtc.Mode]),
+			MmWidth:  int(out.MmWidth),
+			MmHeight: int(out.MmHeight),
+		}
+		arr = append(arr, d)
+	}
+	// The mirrored outputs have the same crtc or the same rect
+	return dedupeDisplays(arr), nil
+}
+
+// monitorDisplays get the RandR 1.5 monitors, the rotation and
+// the refresh rate are of the first output
+func monitorDisplays(c *xgb.Conn, root xproto.Window, ts xproto.Timestamp,
+	modes map[randr.Mode]randr.ModeInfo) ([]DisplayInfo, error) {
+	ms, err := getMonitors(c, root)
+	if err != nil {
+		return nil, err
+	}
+
+	arr := make([]DisplayInfo, 0, len(ms))
+	for _, m := range ms {
+		d := DisplayInfo{Primary: m.Primary, Rect: m.Rect,
+			MmWidth: m.MmWidth, MmHeight: m.MmHeight}
+		if name, err := xproto.GetAtomName(c, m.Name).Reply(); err == nil {
+			d.Name = name.Name
+		}
+
+		if len(m.Outputs) > 0 {
+			out, crtc, err := outputCrtc(c, m.Outputs[0], ts)
+			if err == nil && crtc != nil {
+				d.Rotation = rotationDeg(crtc.Rotation)
+				d.Refresh = modeRefresh(modes[crtc.Mode])
+				if d.MmWidth == 0 {
+					d.MmWidth, d.MmHeight = int(out.MmWidth), int(out.MmHeight)
+				}
+			}
+		}
+		arr = append(arr, d)
+	}
+	return arr, nil
+}
+
+// outputCrtc get the output and its crtc,
+// the crtc is nil if the output is disconnected or disabled
+func outputCrtc(c *xgb.Conn, o randr.Output, ts xproto.Timestamp) (
+	*randr.GetOutputInfoReply, *randr.GetCrtcInfoReply, error) {
+	out, err := randr.GetOutputInfo(c, o, ts).Reply()
+	if err != nil {
+		return nil, nil, err
+	}
+	if out.Connection != randr.ConnectionConnected || out.Crtc == 0 {
+		return out, nil, nil
+	}
+
+	crtc, err := randr.GetCrtcInfo(c, out.Crtc, ts).Reply()
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, crtc, nil
+}
+
+// dedupeDisplays merge the mirrored displays with the same rect,
+// the first is kept, the primary one takes its place
+func dedupeDisplays(arr []DisplayInfo) []DisplayInfo {
+	res := arr[:0:0]
+	for _, d := range arr {
+		i := 0
+		for ; i < len(res); i++ {
+			if res[i].Rect == d.Rect {
+				break
+			}
+		}
+
+		switch {
+		case i == len(res):
+			res = append(res, d)
+		case d.Primary && !res[i].Primary:
+			res[i] = d
+		}
+	}
+	return res
+}
+
+// xineramaDisplays get the Xinerama screens or the root screen,
+// the first screen is the primary
+func xineramaDisplays(c *xgb.Conn) ([]DisplayInfo, error) {
+	var arr []DisplayInfo
+	if err := xinerama.Init(c); err == nil {
+		reply, err := xinerama.QueryScreens(c).Reply()
+		if err == nil {
+			for i, s := range reply.ScreenInfo {
+				arr = append(arr, DisplayInfo{Primary: i == 0,
+					Rect: Rect{Point{X: int(s.XOrg), Y: int(s.YOrg)},
+						Size{W: int(s.Width), H: int(s.Height)}}})
+			}
+		}
+	}
+
+	if len(arr) == 0 {
+		s := xproto.Setup(c).DefaultScreen(c)
+		arr = append(arr, DisplayInfo{Primary: true,
+			Rect:     Rect{Size: Size{W: int(s.WidthInPixels), H: int(s.HeightInPixels)}},
+			MmWidth:  int(s.WidthInMillimeters),
+			MmHeight: int(s.HeightInMillimeters)})
+	}
+	return arr, nil
+}
+
+// rotationDeg get the degrees of the RandR rotation
+func rotationDeg(r uint16) int {
+	switch {
+	case r&randr.RotationRotate90 != 0:
+		return 90
+	case r&randr.RotationRotate180 != 0:
+		return 180
+	case r&randr.RotationRotate270 != 0:
+		return 270
+	}
+	return 0
+}
+
+// modeRefresh get the refresh rate (Hz) of the mode
+func modeRefresh(m randr.ModeInfo) float64 {
+	v := float64(m.Vtotal)
+	if m.ModeFlags&randr.ModeFlagDoubleScan != 0 {
+		v *= 2
+	}
+	if m.ModeFlags&randr.ModeFlagInterlace != 0 {
+		v /= 2
+	}
+
+	if m.Htotal == 0 || v == 0 {
 		return 0
 	}
+	return float64(m.DotClock) / (float64(m.Htotal) * v)
+}
 
-	reply, err := xinerama.QueryScreens(c).Reply()
+// displayRect get the rect of the display index
+func displayRect(display int) (Rect, bool) {
+	if display < 0 {
+		return Rect{}, false
+	}
+
+	arr, err := Displays()
+	if err != nil || display >= len(arr) {
+		return Rect{}, false
+	}
+	return arr[display].Rect, true
+}
+
+// DisplaysNum get the count of displays
+func DisplaysNum() int {
+	arr, err := Displays()
 	if err != nil {
 		return 0
 	}
 
-	return int(reply.Number)
+	return len(arr)
 }
 
-// GetMainId get the main display id
+// GetMainId get the main display id, it's the index of the
+// RandR primary monitor
 func GetMainId() int {
-	conn, err := xgb.NewConn()
+	arr, err := Displays()
 	if err != nil {
 		return -1
 	}
 
-	setup := xproto.Setup(conn)
-	defaultScreen := setup.DefaultScreen(conn)
-	id := -1
-	for i, screen := range setup.Roots {
-		if defaultScreen.Root == screen.Root {
-			id = i
-			break
+	for _, d := range arr {
+		if d.Primary {
+			return d.ID
 		}
 	}
-	return id
+	return 0
 }
 
 // Alert show a alert window
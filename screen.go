@@ -18,10 +18,51 @@ import (
 	"github.com/vcaesar/screenshot"
 )
 
-// GetDisplayBounds gets the display screen bounds
+// DisplayInfo is the monitor information of the Displays()
+type DisplayInfo struct {
+	// ID is the display index, the same as the DisplayID
+	ID int
+	// Name is the RandR output name, e.g. "HDMI-1" (Linux)
+	Name    string
+	Primary bool
+	// Rect is the monitor bounds in the virtual screen
+	Rect
+	// Rotation is the rotation degrees, 0, 90, 180 or 270 (Linux)
+	Rotation int
+	// Refresh is the refresh rate (Hz) (Linux)
+	Refresh float64
+	// MmWidth and MmHeight is the physical size (mm), 0 is unknown
+	MmWidth, MmHeight int
+	// DPI is the horizontal dots per inch of the physical size, 0 is unknown
+	DPI float64
+	// Scale is the scale factor, the desktop Xft.dpi / 96 on Linux,
+	// the same as the ScaleF() on the macOS and Windows
+	Scale float64
+}
+
+// displayDPI get the dpi of the physical width,
+// the physical size is not rotated
+func displayDPI(d DisplayInfo) float64 {
+	w := d.W
+	if d.Rotation == 90 || d.Rotation == 270 {
+		w = d.H
+	}
+
+	if d.MmWidth <= 0 {
+		return 0
+	}
+	return float64(w) * 25.4 / float64(d.MmWidth)
+}
+
+// GetDisplayBounds gets the display screen bounds of the Displays()
 func GetDisplayBounds(i int) (x, y, w, h int) {
-	bs := screenshot.GetDisplayBounds(i)
-	return bs.Min.X, bs.Min.Y, bs.Dx(), bs.Dy()
+	arr, err := Displays()
+	if err != nil || i < 0 || i >= len(arr) {
+		return
+	}
+
+	r := arr[i].Rect
+	return r.X, r.Y, r.W, r.H
 }
 
 // GetDisplayRect gets the display rect
@@ -50,7 +91,13 @@ func Capture(args ...int) (*image.RGBA, error) {
 		x, y, w, h = GetDisplayBounds(displayId)
 	}
 
-	return screenshot.Capture(x, y, w, h)
+	img, err := screenshot.Capture(x, y, w, h)
+	if err != nil || !CaptureCursor {
+		return img, err
+	}
+
+	DrawCursor(img, x, y)
+	return img, nil
 }
 
 // SaveCapture capture screen and save the screenshot to image
@@ -0,0 +1,233 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+#include "../base/os.h"
+#include <stdint.h>
+#include <stdlib.h>
+
+#if defined(USE_X11)
+	#include <X11/Xlib.h>
+	#include <X11/Xutil.h>
+	#include <X11/extensions/XShm.h>
+	#include <pthread.h>
+	#include <sys/ipc.h>
+	#include <sys/shm.h>
+	#include "ximage_c.h"
+
+/* The reusable capturer, keep the display connection and the image buffer. */
+typedef struct _MMCapturer {
+	Display *display;
+	Window root;
+	XImage *image;
+	XShmSegmentInfo shm;
+	int useShm;
+	int attached;
+
+	/* The 32 bits BGRA data of the last capture, it's the image data
+	   or the converted buffer for the other visuals */
+	uint8_t *data;
+	int32_t bytewidth;
+	uint8_t *conv;
+	size_t convSize;
+} MMCapturer;
+
+/* The user set display name, in the base/xdisplay_c.h */
+char *getXDisplay(void);
+
+/* The Xlib error handler is process-wide, the lock serializes the swap,
+   the handler only takes the errors of the capturing display and passes
+   the others to the old handler */
+static pthread_mutex_t capturerLock = PTHREAD_MUTEX_INITIALIZER;
+static Display *capturerDisplay = NULL;
+static XErrorHandler capturerOld = NULL;
+static int capturerError = 0;
+
+static int capturer_error_handler(Display *display, XErrorEvent *event) {
+	if (display != capturerDisplay) {
+		return capturerOld != NULL ? capturerOld(display, event) : 0;
+	}
+
+	capturerError = 1;
+	return 0;
+}
+
+static void capturer_catch(MMCapturer *c) {
+	pthread_mutex_lock(&capturerLock);
+	capturerDisplay = c->display;
+	capturerError = 0;
+	capturerOld = XSetErrorHandler(capturer_error_handler);
+}
+
+/* Restore the old handler, sync the display first for the requests
+   without the reply, return 1 if the display has an error. */
+static int capturer_uncatch(MMCapturer *c, int sync) {
+	if (sync) { XSync(c->display, False); }
+	XSetErrorHandler(capturerOld);
+
+	int err = capturerError;
+	capturerDisplay = NULL;
+	pthread_mutex_unlock(&capturerLock);
+	return err;
+}
+
+MMCapturer *capturer_open(void) {
+	char *name = getXDisplay();
+	Display *display = XOpenDisplay(name);
+	if (display == NULL && name != NULL) {
+		display = XOpenDisplay(NULL);
+	}
+	if (display == NULL) { return NULL; }
+
+	MMCapturer *c = calloc(1, sizeof(MMCapturer));
+	if (c == NULL) {
+		XCloseDisplay(display);
+		return NULL;
+	}
+
+	c->display = display;
+	c->root = XDefaultRootWindow(display);
+	c->useShm = XShmQueryExtension(display);
+	c->shm.shmid = -1;
+	return c;
+}
+
+static void capturer_free_image(MMCapturer *c) {
+	if (c->image == NULL) { return; }
+
+	if (c->attached) {
+		XShmDetach(c->display, &c->shm);
+		XSync(c->display, False);
+		c->attached = 0;
+	}
+	XDestroyImage(c->image);
+	c->image = NULL;
+
+	if (c->shm.shmaddr != NULL) {
+		shmdt(c->shm.shmaddr);
+		c->shm.shmaddr = NULL;
+	}
+	if (c->shm.shmid != -1) {
+		shmctl(c->shm.shmid, IPC_RMID, NULL);
+		c->shm.shmid = -1;
+	}
+}
+
+/* Create the shared memory image, return 0 and disable the MIT-SHM if failed. */
+static int capturer_shm_image(MMCapturer *c, int32_t w, int32_t h) {
+	int screen = XDefaultScreen(c->display);
+	c->image = XShmCreateImage(c->display, XDefaultVisual(c->display, screen),
+			XDefaultDepth(c->display, screen), ZPixmap, NULL, &c->shm, w, h);
+	if (c->image == NULL) { return 0; }
+
+	c->shm.shmid = shmget(IPC_PRIVATE, c->image->bytes_per_line * c->image->height,
+			IPC_CREAT | 0600);
+	if (c->shm.shmid != -1) {
+		c->shm.shmaddr = c->image->data = shmat(c->shm.shmid, NULL, 0);
+	}
+	if (c->shm.shmid == -1 || c->shm.shmaddr == (char *)-1) {
+		c->shm.shmaddr = NULL;
+		capturer_free_image(c);
+		return 0;
+	}
+	c->shm.readOnly = False;
+
+	/* The remote X server can't attach the segment */
+	capturer_catch(c);
+	XShmAttach(c->display, &c->shm);
+	int shmError = capturer_uncatch(c, 1);
+
+	/* Mark the segment destroyed, it's freed after the last detach */
+	shmctl(c->shm.shmid, IPC_RMID, NULL);
+	c->shm.shmid = -1;
+	if (shmError) {
+		capturer_free_image(c);
+		return 0;
+	}
+
+	c->attached = 1;
+	return 1;
+}
+
+/* Get the root window size, the capture rect must be inside it. */
+void capturer_root_size(MMCapturer *c, int32_t *w, int32_t *h) {
+	XWindowAttributes attr;
+	if (!XGetWindowAttributes(c->display, c->root, &attr)) {
+		*w = 0; *h = 0;
+		return;
+	}
+	*w = attr.width; *h = attr.height;
+}
+
+static int capturer_image(MMCapturer *c, int32_t x, int32_t y, int32_t w, int32_t h) {
+	if (c->image != NULL && (c->image->width != w || c->image->height != h)) {
+		capturer_free_image(c);
+	}
+
+	if (c->useShm && c->image == NULL && !capturer_shm_image(c, w, h)) {
+		c->useShm = 0;
+	}
+
+	/* The rect outside the resized root is the BadMatch,
+	   the default handler exits the process */
+	int ok;
+	capturer_catch(c);
+	if (c->useShm) {
+		ok = XShmGetImage(c->display, c->root, c->image, x, y, AllPlanes);
+	} else if (c->image == NULL) {
+		c->image = XGetImage(c->display, c->root, x, y, w, h, AllPlanes, ZPixmap);
+		ok = c->image != NULL;
+	} else {
+		ok = XGetSubImage(c->display, c->root, x, y, w, h,
+				AllPlanes, ZPixmap, c->image, 0, 0) != NULL;
+	}
+
+	/* The get image requests have the reply, the errors are handled */
+	if (capturer_uncatch(c, 0)) { return 0; }
+	return ok;
+}
+
+/* Capture the root window rect into the reusable image, return 0 if failed. */
+int capturer_grab(MMCapturer *c, int32_t x, int32_t y, int32_t w, int32_t h) {
+	if (!capturer_image(c, x, y, w, h)) { return 0; }
+
+	XImage *image = c->image;
+	if (ximage_is_bgra(image)) {
+		ximage_fill_alpha(image);
+		c->data = (uint8_t *)image->data;
+		c->bytewidth = image->bytes_per_line;
+		return 1;
+	}
+
+	/* The 16, 30 bits or the other visuals, normalise by the color masks */
+	size_t size = (size_t)w * h * 4;
+	if (c->convSize < size) {
+		free(c->conv);
+		c->conv = malloc(size);
+		c->convSize = c->conv == NULL ? 0 : size;
+	}
+	if (c->conv == NULL) { return 0; }
+
+	ximage_convert(image, c->conv);
+	c->data = c->conv;
+	c->bytewidth = w * 4;
+	return 1;
+}
+
+void capturer_close(MMCapturer *c) {
+	if (c == NULL) { return; }
+
+	capturer_free_image(c);
+	XCloseDisplay(c->display);
+	free(c->conv);
+	free(c);
+}
+
+#endif
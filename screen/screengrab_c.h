@@ -10,6 +10,7 @@
 	#include <X11/Xlib.h>
 	#include <X11/Xutil.h>
 	#include "../base/xdisplay_c.h"
+	#include "ximage_c.h"
 #elif defined(IS_WINDOWS)
 	#include <string.h>
 #endif
@@ -117,10 +118,18 @@ MMBitmapRef copyMMBitmapFromDisplayInRect(MMRectInt32 rect, int32_t display_id,
 	XCloseDisplay(display);
 	if (image == NULL) { return NULL; }
 
-	bitmap = createMMBitmap_c((uint8_t *)image->data, 
-				s.w, s.h, (size_t)image->bytes_per_line, 
-				(uint8_t)image->bits_per_pixel, (uint8_t)image->bits_per_pixel / 8);
-	image->data = NULL; /* Steal ownership of bitmap data so we don't have to copy it. */
+	if (ximage_is_bgra(image)) {
+		ximage_fill_alpha(image);
+		bitmap = createMMBitmap_c((uint8_t *)image->data, 
+					s.w, s.h, (size_t)image->bytes_per_line, 32, 4);
+		image->data = NULL; /* Steal ownership of bitmap data so we don't have to copy it. */
+	} else {
+		/* The 16, 30 bits or the other visuals, normalise by the color masks */
+		uint8_t *buffer = malloc((size_t)image->width * image->height * 4);
+		if (buffer != NULL) { ximage_convert(image, buffer); }
+		bitmap = buffer == NULL ? NULL : 
+			createMMBitmap_c(buffer, image->width, image->height, image->width * 4, 32, 4);
+	}
 	XDestroyImage(image);
 
 	return bitmap;
@@ -0,0 +1,72 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+#pragma once
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+#include <stdint.h>
+
+/* Get the shift and the bits number of the visual color mask. */
+static void ximage_mask(unsigned long mask, int *shift, int *bits) {
+	*shift = 0; *bits = 0;
+	if (mask == 0) { return; }
+
+	while (!(mask & 1)) { mask >>= 1; (*shift)++; }
+	while (mask & 1) { mask >>= 1; (*bits)++; }
+}
+
+/* Scale the color channel of the pixel to 8 bits. */
+static uint8_t ximage_channel(unsigned long pixel, int shift, int bits) {
+	if (bits == 0) { return 0; }
+
+	unsigned long max = (1UL << bits) - 1;
+	unsigned long v = (pixel >> shift) & max;
+	if (bits >= 8) { return (uint8_t)(v >> (bits - 8)); }
+	return (uint8_t)((v * 255 + max / 2) / max);
+}
+
+/* Check the image is the 32 bits BGRX, same as the MMBitmap layout. */
+static int ximage_is_bgra(XImage *image) {
+	return image->bits_per_pixel == 32 && image->byte_order == LSBFirst &&
+		image->red_mask == 0xff0000 && image->green_mask == 0xff00 &&
+		image->blue_mask == 0xff;
+}
+
+/* Set the BGRX image padding byte to the opaque alpha. */
+static void ximage_fill_alpha(XImage *image) {
+	for (int y = 0; y < image->height; y++) {
+		uint8_t *row = (uint8_t *)image->data + y * image->bytes_per_line;
+		for (int x = 0; x < image->width; x++) {
+			row[x * 4 + 3] = 0xff;
+		}
+	}
+}
+
+/* Convert the image to the 32 bits BGRA dst (width * 4 bytes per line)
+   by the visual color masks, the XGetPixel handles the bits and byte order. */
+static void ximage_convert(XImage *image, uint8_t *dst) {
+	int rs, rb, gs, gb, bs, bb;
+	ximage_mask(image->red_mask, &rs, &rb);
+	ximage_mask(image->green_mask, &gs, &gb);
+	ximage_mask(image->blue_mask, &bs, &bb);
+
+	for (int y = 0; y < image->height; y++) {
+		for (int x = 0; x < image->width; x++) {
+			unsigned long pixel = XGetPixel(image, x, y);
+			uint8_t *d = dst + (y * image->width + x) * 4;
+
+			d[0] = ximage_channel(pixel, bs, bb);
+			d[1] = ximage_channel(pixel, gs, gb);
+			d[2] = ximage_channel(pixel, rs, rb);
+			d[3] = 0xff;
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+//go:build !darwin && !windows
+// +build !darwin,!windows
+
+package robotgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/robotn/xgb"
+	"github.com/robotn/xgb/randr"
+	"github.com/robotn/xgb/xproto"
+	"github.com/vcaesar/tt"
+)
+
+// maskPixel scale the 8 bits color to the visual masks
+func maskPixel(c color.RGBA, r, g, b uint32) uint32 {
+	scale := func(v uint8, mask uint32) uint32 {
+		shift, bits := maskShift(mask)
+		p := uint32(v) << 8 >> (16 - bits)
+		if bits > 8 {
+			p = uint32(v)<<(bits-8) | uint32(v)>>(16-bits)
+		}
+		return p << shift
+	}
+	return scale(c.R, r) | scale(c.G, g) | scale(c.B, b)
+}
+
+var testColors = []color.RGBA{
+	{0xff, 0, 0, 0xff}, {0, 0xff, 0, 0xff}, {0, 0, 0xff, 0xff},
+	{0xff, 0xff, 0xff, 0xff}, {0x80, 0x40, 0xc0, 0xff},
+}
+
+func near(a, b color.RGBA) bool {
+	d := func(x, y uint8) bool { return abs(int(x)-int(y)) < 9 }
+	return d(a.R, b.R) && d(a.G, b.G) && d(a.B, b.B) && a.A == b.A
+}
+
+func TestXPixFormat(t *testing.T) {
+	formats := []xPixFormat{
+		{bpp: 16, pad: 32, r: 0xf800, g: 0x07e0, b: 0x001f},
+		{bpp: 32, pad: 32, r: 0xff0000, g: 0xff00, b: 0xff},
+		{bpp: 32, pad: 32, msb: true, r: 0xff0000, g: 0xff00, b: 0xff},
+		{bpp: 32, pad: 32, r: 0x3ff00000, g: 0xffc00, b: 0x3ff},
+		{bpp: 24, pad: 32, r: 0xff0000, g: 0xff00, b: 0xff},
+	}
+
+	for _, f := range formats {
+		// The odd width has the scanline padding
+		w, bpp := len(testColors), f.bpp/8
+		stride := (w*f.bpp + f.pad - 1) / f.pad * f.pad / 8
+		data := make([]byte, stride*2)
+		for i, c := range testColors {
+			p := maskPixel(c, f.r, f.g, f.b)
+			for j := 0; j < bpp; j++ {
+				k := j
+				if f.msb {
+					k = bpp - 1 - j
+				}
+				data[stride+i*bpp+k] = byte(p >> (8 * j))
+			}
+		}
+
+		img := image.NewRGBA(image.Rect(0, 0, w+1, 3))
+		tt.Nil(t, f.draw(img, image.Rect(1, 1, w+1, 3), data))
+		for i, c := range testColors {
+			tt.True(t, near(c, img.RGBAAt(i+1, 2)), fmt.Sprint(f, c, img.RGBAAt(i+1, 2)))
+		}
+		tt.Equal(t, color.RGBA{}, img.RGBAAt(0, 0))
+	}
+
+	f := xPixFormat{bpp: 32, pad: 32, r: 0xff0000, g: 0xff00, b: 0xff}
+	tt.NotNil(t, f.draw(image.NewRGBA(image.Rect(0, 0, 4, 4)), image.Rect(0, 0, 4, 4), nil))
+}
+
+// startXvfb start the Xvfb with the screen depth, return the display name
+func startXvfb(t *testing.T, depth int) string {
+	path, err := exec.LookPath("Xvfb")
+	if err != nil {
+		t.Skip("Xvfb is not installed")
+	}
+
+	disp := fmt.Sprintf(":%d", 90+depth)
+	cmd := exec.Command(path, disp, "-screen", "0", fmt.Sprintf("320x240x%d", depth),
+		"-nolisten", "tcp")
+	if err := cmd.Start(); err != nil {
+		t.Skip("Xvfb start error: ", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	for i := 0; i < 50; i++ {
+		if c, err := xgb.NewConnDisplay(disp); err == nil {
+			c.Close()
+			return disp
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Skip("Xvfb is not ready")
+	return ""
+}
+
+// fillRoot draw the test colors on the root window
+func fillRoot(t *testing.T, disp string) {
+	c, err := xgb.NewConnDisplay(disp)
+	tt.Nil(t, err)
+	defer c.Close()
+
+	screen := xproto.Setup(c).DefaultScreen(c)
+	f, err := xImageFormat(c, screen.RootDepth, screen.RootVisual)
+	tt.Nil(t, err)
+
+	gc, _ := xproto.NewGcontextId(c)
+	xproto.CreateGC(c, gc, xproto.Drawable(screen.Root), 0, nil)
+	for i, col := range testColors {
+		xproto.ChangeGC(c, gc, xproto.GcForeground, []uint32{maskPixel(col, f.r, f.g, f.b)})
+		xproto.PolyFillRectangle(c, xproto.Drawable(screen.Root), gc,
+			[]xproto.Rectangle{{X: int16(i * 10), Y: 0, Width: 10, Height: 10}})
+	}
+	xproto.GetInputFocus(c).Reply()
+}
+
+func TestXvfbCapture(t *testing.T) {
+	for _, depth := range []int{16, 24, 30} {
+		t.Run(fmt.Sprint(depth), func(t *testing.T) {
+			disp := startXvfb(t, depth)
+			t.Setenv("DISPLAY", disp)
+			// reopen the main display, it keeps the killed server of the last depth
+			tt.Nil(t, SetXDisplayName(disp))
+			t.Cleanup(func() { SetXDisplayName("") })
+			fillRoot(t, disp)
+
+			bit := CaptureScreen(0, 0, 50, 10)
+			tt.NotNil(t, bit)
+			img := ToRGBA(bit)
+			FreeBitmap(bit)
+
+			cp, err := NewCapturer()
+			tt.Nil(t, err)
+			defer cp.Close()
+			img1, err := cp.CaptureRGBA(0, 0, 50, 10)
+			tt.Nil(t, err)
+
+			for i, c := range testColors {
+				tt.True(t, near(c, img.RGBAAt(i*10+5, 5)), fmt.Sprint(c, img.RGBAAt(i*10+5, 5)))
+				tt.True(t, near(c, img1.RGBAAt(i*10+5, 5)), fmt.Sprint(c, img1.RGBAAt(i*10+5, 5)))
+			}
+		})
+	}
+}
+
+func TestDisplayMeta(t *testing.T) {
+	m := randr.ModeInfo{DotClock: 148500000, Htotal: 2200, Vtotal: 1125}
+	tt.Equal(t, 60.0, modeRefresh(m))
+	m.ModeFlags = randr.ModeFlagInterlace
+	tt.Equal(t, 120.0, modeRefresh(m))
+	tt.Equal(t, 0.0, modeRefresh(randr.ModeInfo{}))
+
+	tt.Equal(t, 0, rotationDeg(randr.RotationRotate0))
+	tt.Equal(t, 90, rotationDeg(randr.RotationRotate90|randr.RotationReflectX))
+	tt.Equal(t, 270, rotationDeg(randr.RotationRotate270))
+
+	d := DisplayInfo{Rect: Rect{Size: Size{W: 1920, H: 1080}}, MmWidth: 508}
+	tt.Equal(t, 96.0, displayDPI(d))
+	d.Rect.W, d.Rect.H, d.Rotation = 1080, 1920, 90
+	tt.Equal(t, 96.0, displayDPI(d))
+	tt.Equal(t, 0.0, displayDPI(DisplayInfo{}))
+}
+
+func TestDesktopScale(t *testing.T) {
+	data := []byte{0, 0, 0, 0}
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = xsetting(data, 0, "Xft/DPI", binary.LittleEndian.AppendUint32(nil, 144*1024))
+
+	tt.Equal(t, 1.5, desktopScale(data, "Xft.dpi:\t96\n"))
+	tt.Equal(t, 1.25, desktopScale(nil, "Xft.antialias:\t1\nXft.dpi:\t120\n"))
+	tt.Equal(t, 3.0, desktopScale(nil, "*Xft.dpi: 288.0"))
+	tt.Equal(t, 1.0, desktopScale(nil, ""))
+}
+
+func TestDedupeDisplays(t *testing.T) {
+	r := Rect{Size: Size{W: 1920, H: 1080}}
+	r1 := Rect{Point{X: 1920}, Size{W: 1280, H: 1024}}
+	arr := dedupeDisplays([]DisplayInfo{
+		{Name: "eDP-1", Rect: r},
+		{Name: "DP-1", Rect: r1},
+		{Name: "HDMI-1", Primary: true, Rect: r},
+		{Name: "DP-2", Rect: r1},
+	})
+
+	tt.Equal(t, 2, len(arr))
+	tt.Equal(t, "HDMI-1", arr[0].Name)
+	tt.True(t, arr[0].Primary)
+	tt.Equal(t, "DP-1", arr[1].Name)
+}
+
+func TestParseMonitors(t *testing.T) {
+	buf := make([]byte, 32+24+8)
+	xgb.Put32(buf[12:], 1)
+	b := buf[32:]
+	xgb.Put32(b, 300)
+	b[4] = 1
+	xgb.Put16(b[6:], 2)
+	x := int16(-1920)
+	xgb.Put16(b[8:], uint16(x))
+	xgb.Put16(b[12:], 1920)
+	xgb.Put16(b[14:], 1080)
+	xgb.Put32(b[16:], 508)
+	xgb.Put32(b[20:], 286)
+	xgb.Put32(b[24:], 63)
+	xgb.Put32(b[28:], 64)
+
+	arr, err := parseMonitors(buf)
+	tt.Nil(t, err)
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, xproto.Atom(300), arr[0].Name)
+	tt.True(t, arr[0].Primary)
+	tt.Equal(t, Rect{Point{X: -1920}, Size{W: 1920, H: 1080}}, arr[0].Rect)
+	tt.Equal(t, 508, arr[0].MmWidth)
+	tt.Equal(t, []randr.Output{63, 64}, arr[0].Outputs)
+
+	_, err = parseMonitors(buf[:40])
+	tt.NotNil(t, err)
+}
+
+func TestXvfbDisplays(t *testing.T) {
+	disp := startXvfb(t, 24)
+	c, err := xgb.NewConnDisplay(disp)
+	tt.Nil(t, err)
+	defer c.Close()
+
+	var arr []DisplayInfo
+	if monitors, err := initRandr(c); err == nil {
+		arr, err = randrDisplays(c, monitors)
+		tt.Nil(t, err)
+	}
+	if len(arr) == 0 {
+		arr, err = xineramaDisplays(c)
+		tt.Nil(t, err)
+	}
+
+	tt.Equal(t, 1, len(arr))
+	tt.Equal(t, Rect{Size: Size{W: 320, H: 240}}, arr[0].Rect)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+/*
+Package visualtest is the golden image assertions of the screen for Go tests,
+the goldens are the png files, run the tests with the VISUALTEST_UPDATE=1
+to regenerate them, or the -update flag if the test package defines it.
+
+	func TestMain(m *testing.M) {
+		// Run the tests in the Xvfb if there is no display
+		os.Exit(visualtest.Main(m))
+	}
+
+	func TestDialog(t *testing.T) {
+		openDialog()
+		visualtest.AssertScreenMatches(t, "dialog", robotgo.Rect{
+			Point: robotgo.Point{X: 100, Y: 100}, Size: robotgo.Size{W: 400, H: 300}},
+			visualtest.Options{Ignore: []robotgo.Rect{clockRect}})
+	}
+
+	VISUALTEST_UPDATE=1 go test -run TestDialog
+*/
+package visualtest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// updating return true if the VISUALTEST_UPDATE environment variable is set,
+// or the -update flag of the test package is set, the flag is not registered
+// here, so it doesn't conflict with the test package and the other libraries
+func updating() bool {
+	if v, err := strconv.ParseBool(os.Getenv("VISUALTEST_UPDATE")); err == nil && v {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// Options is the assertion options, the zero value is the default
+type Options struct {
+	// Dir is the golden images directory, default is "testdata/golden"
+	Dir string
+	// OutDir is the actual and diff images directory of the failures,
+	// default is "testdata/failed"
+	OutDir string
+
+	// Tolerance is the pixel color tolerance (0.0 ~ 1.0),
+	// default is 0.02, use a tiny value for the exact match
+	Tolerance float64
+	// MaxDiff is the max ratio of the different pixels (0.0 ~ 1.0), default is 0
+	MaxDiff float64
+	// Ignore is the dynamic regions (the clock, the cursor...) to skip,
+	// the rects are the image points, (0, 0) is the region top-left
+	Ignore []robotgo.Rect
+	// Distance is the color distance, default is the robotgo.ColorDistance
+	// or the robotgo.DistanceRGB
+	Distance robotgo.DistanceFunc
+
+	// Update write the golden images, the same as the VISUALTEST_UPDATE
+	Update bool
+}
+
+func (opt Options) withDefault() Options {
+	if opt.Dir == "" {
+		opt.Dir = filepath.Join("testdata", "golden")
+	}
+	if opt.OutDir == "" {
+		opt.OutDir = filepath.Join("testdata", "failed")
+	}
+	if opt.Tolerance == 0 {
+		opt.Tolerance = 0.02
+	}
+	if opt.Distance == nil {
+		opt.Distance = robotgo.ColorDistance
+	}
+	if opt.Distance == nil {
+		opt.Distance = robotgo.DistanceRGB
+	}
+	return opt
+}
+
+func getOpt(opts []Options) Options {
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt.withDefault()
+}
+
+// Result is the images compare result
+type Result struct {
+	// Diff is the diff image, the golden is faded, the different pixels
+	// are red and the ignored regions are blue
+	Diff *image.RGBA
+	// Count is the number of the different pixels
+	Count int
+	// Ratio is the Count ratio of the compared pixels
+	Ratio float64
+}
+
+// ErrSize is the error of the images have the different size
+var ErrSize = errors.New("the images size are different")
+
+func toRGBA(img image.Image) *image.RGBA {
+	if m, ok := img.(*image.RGBA); ok && m.Rect.Min == (image.Point{}) {
+		return m
+	}
+
+	b := img.Bounds()
+	m := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(m, m.Rect, img, b.Min, draw.Src)
+	return m
+}
+
+// Compare compare the actual image to the golden image,
+// return the ErrSize if the size is different
+func Compare(golden, actual image.Image, opts ...Options) (Result, error) {
+	opt := getOpt(opts)
+	g, a := toRGBA(golden), toRGBA(actual)
+	if g.Rect != a.Rect {
+		return Result{}, ErrSize
+	}
+
+	ignore := make([]image.Rectangle, len(opt.Ignore))
+	for i, r := range opt.Ignore {
+		ignore[i] = image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+	}
+	skip := func(x, y int) bool {
+		for _, r := range ignore {
+			if image.Pt(x, y).In(r) {
+				return true
+			}
+		}
+		return false
+	}
+
+	res := Result{Diff: image.NewRGBA(g.Rect)}
+	total := 0
+	for y := 0; y < g.Rect.Dy(); y++ {
+		for x := 0; x < g.Rect.Dx(); x++ {
+			i := g.PixOffset(x, y)
+			p0, p1 := g.Pix[i:i+4:i+4], a.Pix[i:i+4:i+4]
+
+			// The faded golden
+			v := uint8((299*int(p0[0])+587*int(p0[1])+114*int(p0[2]))/1000/2 + 128)
+			res.Diff.SetRGBA(x, y, color.RGBA{v, v, v, 0xff})
+			if skip(x, y) {
+				continue
+			}
+
+			total++
+			c0 := robotgo.Color{R: p0[0], G: p0[1], B: p0[2]}
+			c1 := robotgo.Color{R: p1[0], G: p1[1], B: p1[2]}
+			if opt.Distance(c0, c1) > opt.Tolerance {
+				res.Count++
+				res.Diff.SetRGBA(x, y, color.RGBA{0xff, 0, 0, 0xff})
+			}
+		}
+	}
+
+	for _, r := range opt.Ignore {
+		robotgo.DrawRect(res.Diff, r, color.RGBA{0, 0x80, 0xff, 0xff}, 1)
+	}
+	if total > 0 {
+		res.Ratio = float64(res.Count) / float64(total)
+	}
+	return res, nil
+}
+
+// capture capture the region, the region is nil (the screen),
+// a Rect or an image.Image
+func capture(region interface{}) (image.Image, error) {
+	switch r := region.(type) {
+	case nil:
+		return robotgo.CaptureImg()
+	case robotgo.Rect:
+		return robotgo.CaptureImg(r.X, r.Y, r.W, r.H)
+	case *robotgo.Rect:
+		return robotgo.CaptureImg(r.X, r.Y, r.W, r.H)
+	case image.Image:
+		return r, nil
+	}
+	return nil, fmt.Errorf("visualtest: the region type %T is not supported", region)
+}
+
+// AssertScreenMatches capture the region and compare it to the golden
+// image "<name>.png", the region is nil (the screen), a robotgo.Rect
+// or an image.Image, write the golden if the VISUALTEST_UPDATE is set.
+//
+// It writes the "<name>.actual.png" and "<name>.diff.png" to the OutDir
+// and reports the t.Errorf() if it's not matched, return true if matched
+//
+// Examples:
+//
+//	visualtest.AssertScreenMatches(t, "toolbar", robotgo.Rect{
+//		Point: robotgo.Point{X: 0, Y: 0}, Size: robotgo.Size{W: 800, H: 40}})
+func AssertScreenMatches(t testing.TB, name string, region interface{}, opts ...Options) bool {
+	t.Helper()
+	img, err := capture(region)
+	if err != nil {
+		t.Errorf("visualtest: capture %q errors is: %v", name, err)
+		return false
+	}
+	return AssertImageMatches(t, name, img, opts...)
+}
+
+// AssertImageMatches compare the image to the golden image "<name>.png",
+// the same as AssertScreenMatches()
+func AssertImageMatches(t testing.TB, name string, img image.Image, opts ...Options) bool {
+	t.Helper()
+	opt := getOpt(opts)
+	path := filepath.Join(opt.Dir, filepath.FromSlash(name)+".png")
+
+	if opt.Update || updating() {
+		err := writePng(img, path)
+		if err != nil {
+			t.Errorf("visualtest: update %q errors is: %v", name, err)
+			return false
+		}
+		t.Logf("visualtest: updated the golden %s", path)
+		return true
+	}
+
+	golden, err := robotgo.Read(path)
+	if err != nil {
+		out := failed(t, name, img, nil, opt)
+		t.Errorf("visualtest: read the golden %s errors is: %v, run the test with"+
+			" the VISUALTEST_UPDATE=1 to create it, the actual is %s", path, err, out)
+		return false
+	}
+
+	res, err := Compare(golden, img, opt)
+	if err != nil {
+		out := failed(t, name, img, nil, opt)
+		t.Errorf("visualtest: %q %v, the golden is %v, the actual is %v (%s)",
+			name, err, golden.Bounds().Size(), img.Bounds().Size(), out)
+		return false
+	}
+
+	if res.Ratio > opt.MaxDiff {
+		out := failed(t, name, img, res.Diff, opt)
+		t.Errorf("visualtest: %q is not matched, %d pixels (%.4f) are different,"+
+			" the max is %.4f, the actual and diff are %s", name, res.Count,
+			res.Ratio, opt.MaxDiff, out)
+		return false
+	}
+	return true
+}
+
+// failed write the actual and diff images, return the actual path pattern
+func failed(t testing.TB, name string, img image.Image, diff *image.RGBA, opt Options) string {
+	t.Helper()
+	base := filepath.Join(opt.OutDir, filepath.FromSlash(name))
+	if err := writePng(img, base+".actual.png"); err != nil {
+		t.Logf("visualtest: write the actual image errors is: %v", err)
+	}
+
+	if diff != nil {
+		if err := writePng(diff, base+".diff.png"); err != nil {
+			t.Logf("visualtest: write the diff image errors is: %v", err)
+		}
+	}
+	return base + ".{actual,diff}.png"
+}
+
+func writePng(img image.Image, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return robotgo.SavePng(img, path)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package visualtest
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-vgo/robotgo"
+	"github.com/vcaesar/tt"
+)
+
+// The test package defines the -update flag, the visualtest doesn't register it
+var update = flag.Bool("update", false, "update the golden images")
+
+// fakeT record the errors of the assertions
+type fakeT struct {
+	testing.TB
+	errs []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Logf(format string, args ...interface{}) {}
+
+func testImg() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 60, 40))
+	draw.Draw(img, img.Rect, image.NewUniform(color.RGBA{0xe0, 0xe0, 0xe0, 0xff}),
+		image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(10, 10, 30, 20), image.NewUniform(color.RGBA{0x20, 0x60, 0xc0, 0xff}),
+		image.Point{}, draw.Src)
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	a, b := testImg(), testImg()
+	res, err := Compare(a, b)
+	tt.Nil(t, err)
+	tt.Equal(t, 0, res.Count)
+
+	// The anti-aliasing noise is in the tolerance
+	b.Pix[0] += 2
+	b.SetRGBA(50, 30, color.RGBA{0, 0, 0, 0xff})
+	b.SetRGBA(51, 30, color.RGBA{0, 0, 0, 0xff})
+	res, err = Compare(a, b)
+	tt.Nil(t, err)
+	tt.Equal(t, 2, res.Count)
+	tt.Equal(t, 2.0/2400, res.Ratio)
+	tt.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, res.Diff.RGBAAt(50, 30))
+	tt.Equal(t, color.RGBA{0xf0, 0xf0, 0xf0, 0xff}, res.Diff.RGBAAt(1, 1))
+
+	ignore := robotgo.Rect{Point: robotgo.Point{X: 45, Y: 25}, Size: robotgo.Size{W: 10, H: 10}}
+	res, err = Compare(a, b, Options{Ignore: []robotgo.Rect{ignore}})
+	tt.Nil(t, err)
+	tt.Equal(t, 0, res.Count)
+	tt.Equal(t, color.RGBA{0, 0x80, 0xff, 0xff}, res.Diff.RGBAAt(45, 25))
+
+	// The sub image is the same
+	c := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	draw.Draw(c, image.Rect(20, 20, 80, 60), a, image.Point{}, draw.Src)
+	res, err = Compare(a, c.SubImage(image.Rect(20, 20, 80, 60)))
+	tt.Nil(t, err)
+	tt.Equal(t, 0, res.Count)
+
+	_, err = Compare(a, c)
+	tt.Equal(t, ErrSize, err)
+}
+
+func TestAssertImageMatches(t *testing.T) {
+	// Run without the update of the go test
+	defer func(v bool) { *update = v }(*update)
+	*update = false
+	t.Setenv("VISUALTEST_UPDATE", "")
+
+	dir := t.TempDir()
+	opt := Options{Dir: filepath.Join(dir, "golden"), OutDir: filepath.Join(dir, "failed")}
+
+	ft := &fakeT{}
+	tt.False(t, AssertImageMatches(ft, "ui/button", testImg(), opt))
+	tt.Equal(t, 1, len(ft.errs))
+	_, err := os.Stat(filepath.Join(dir, "failed", "ui", "button.actual.png"))
+	tt.Nil(t, err)
+
+	// Update by the VISUALTEST_UPDATE
+	t.Setenv("VISUALTEST_UPDATE", "1")
+	tt.True(t, AssertImageMatches(ft, "ui/button", testImg(), opt))
+	t.Setenv("VISUALTEST_UPDATE", "")
+	tt.True(t, AssertImageMatches(ft, "ui/button", testImg(), opt))
+
+	// Update by the -update flag of the test package
+	*update = true
+	tt.True(t, AssertImageMatches(ft, "ui/other", testImg(), opt))
+	*update = false
+	tt.True(t, AssertImageMatches(ft, "ui/other", testImg(), opt))
+	tt.Equal(t, 1, len(ft.errs))
+
+	changed := testImg()
+	draw.Draw(changed, image.Rect(40, 10, 50, 20), image.NewUniform(color.Black),
+		image.Point{}, draw.Src)
+	tt.False(t, AssertScreenMatches(ft, "ui/button", changed, opt))
+	tt.Equal(t, 2, len(ft.errs))
+	diff, err := robotgo.Read(filepath.Join(dir, "failed", "ui", "button.diff.png"))
+	tt.Nil(t, err)
+	tt.Equal(t, color.RGBA{0xff, 0, 0, 0xff}, color.RGBAModel.Convert(diff.At(45, 15)))
+
+	opt.MaxDiff = 0.05
+	tt.True(t, AssertScreenMatches(ft, "ui/button", changed, opt))
+
+	opt.MaxDiff = 0
+	tt.False(t, AssertScreenMatches(ft, "ui/button", changed.SubImage(image.Rect(0, 0, 30, 30)), opt))
+	tt.False(t, AssertScreenMatches(ft, "ui/button", 1, opt))
+	tt.Equal(t, 4, len(ft.errs))
+}
+
+func TestXvfbAssertScreenMatches(t *testing.T) {
+	if _, err := exec.LookPath("Xvfb"); err != nil {
+		t.Skip("Xvfb is not installed")
+	}
+	x, err := StartXvfb("320x240x24")
+	if err != nil {
+		t.Skip("Xvfb start error: ", err)
+	}
+	defer x.Stop()
+	t.Setenv("DISPLAY", x.Display)
+
+	defer func(v bool) { *update = v }(*update)
+	*update = false
+	t.Setenv("VISUALTEST_UPDATE", "")
+
+	dir := t.TempDir()
+	opt := Options{Dir: filepath.Join(dir, "golden"), OutDir: filepath.Join(dir, "failed")}
+	region := robotgo.Rect{Point: robotgo.Point{X: 10, Y: 20}, Size: robotgo.Size{W: 64, H: 48}}
+
+	opt.Update = true
+	tt.True(t, AssertScreenMatches(t, "xvfb/root", region, opt))
+	golden, err := robotgo.Read(filepath.Join(dir, "golden", "xvfb", "root.png"))
+	tt.Nil(t, err)
+	tt.Equal(t, image.Rect(0, 0, 64, 48), golden.Bounds())
+
+	opt.Update = false
+	tt.True(t, AssertScreenMatches(t, "xvfb/root", region, opt))
+
+	// The other size region is not matched
+	ft := &fakeT{}
+	region.W = 32
+	tt.False(t, AssertScreenMatches(ft, "xvfb/root", region, opt))
+	tt.Equal(t, 1, len(ft.errs))
+}
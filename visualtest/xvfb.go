@@ -0,0 +1,124 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package visualtest
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Xvfb is the X virtual framebuffer server for the headless tests
+type Xvfb struct {
+	// Display is the display name, e.g. ":99"
+	Display string
+	cmd     *exec.Cmd
+}
+
+// StartXvfb start the Xvfb server on a free display, the screen is
+// the "WxHxDepth" of the screen 0, default is "1280x1024x24",
+// call the Stop() to kill it
+//
+// Examples:
+//
+//	x, err := visualtest.StartXvfb("1920x1080x24")
+//	if err != nil {
+//		t.Skip(err)
+//	}
+//	defer x.Stop()
+//	os.Setenv("DISPLAY", x.Display)
+func StartXvfb(screen ...string) (*Xvfb, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("visualtest: Xvfb is only supported on Linux")
+	}
+
+	size := "1280x1024x24"
+	if len(screen) > 0 && screen[0] != "" {
+		size = screen[0]
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// The Xvfb writes the display number to the fd 3 if it's ready
+	cmd := exec.Command("Xvfb", "-displayfd", "3", "-screen", "0", size, "-nolisten", "tcp")
+	cmd.ExtraFiles = []*os.File{w}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(r).ReadString('\n')
+		ch <- strings.TrimSpace(line)
+	}()
+
+	select {
+	case n := <-ch:
+		if n == "" {
+			cmd.Wait()
+			return nil, errors.New("visualtest: Xvfb exited")
+		}
+		return &Xvfb{Display: ":" + n, cmd: cmd}, nil
+	case <-time.After(10 * time.Second):
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, errors.New("visualtest: Xvfb start timeout")
+	}
+}
+
+// Stop kill the Xvfb server
+func (x *Xvfb) Stop() error {
+	if err := x.cmd.Process.Kill(); err != nil {
+		return err
+	}
+
+	x.cmd.Wait()
+	return nil
+}
+
+// Main run the tests in the Xvfb if the DISPLAY is not set on Linux,
+// it's used in the TestMain, the Xvfb screen is the VISUALTEST_SCREEN
+// environment variable or the default of the StartXvfb()
+//
+// Examples:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(visualtest.Main(m))
+//	}
+func Main(m *testing.M) int {
+	if runtime.GOOS != "linux" || os.Getenv("DISPLAY") != "" {
+		return m.Run()
+	}
+
+	x, err := StartXvfb(os.Getenv("VISUALTEST_SCREEN"))
+	if err != nil {
+		log.Println("visualtest: start the Xvfb errors is: ", err)
+		return m.Run()
+	}
+	defer x.Stop()
+
+	os.Setenv("DISPLAY", x.Display)
+	log.Println("visualtest: run the tests in the Xvfb", x.Display)
+	return m.Run()
+}
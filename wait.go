@@ -0,0 +1,184 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"time"
+)
+
+// WaitOpts is the wait options, the zero value is the default
+type WaitOpts struct {
+	// Timeout is the wait timeout (ms), default is 10000
+	Timeout int
+	// Interval is the poll interval (ms), default is 100
+	Interval int
+	// Tolerance is the color tolerance (0.0 ~ 1.0), default is the DefaultTolerance
+	Tolerance float64
+	// Distance is the color distance, default is the ColorDistance
+	Distance DistanceFunc
+}
+
+// TimeoutError is the wait timeout error, with the last captured frame
+type TimeoutError struct {
+	// Op is the wait function name
+	Op string
+	// Frame is the last captured frame of the region, Origin is its screen point
+	Frame  *image.RGBA
+	Origin Point
+}
+
+// Error return the timeout error message
+func (e *TimeoutError) Error() string {
+	return e.Op + ": wait timeout"
+}
+
+// Timeout is the timeout error
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+func waitOpt(opts []WaitOpts) WaitOpts {
+	var opt WaitOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = 10000
+	}
+	if opt.Interval <= 0 {
+		opt.Interval = 100
+	}
+	if opt.Tolerance <= 0 {
+		opt.Tolerance = DefaultTolerance
+	}
+	return opt
+}
+
+func (opt WaitOpts) colorOpts() ColorOpts {
+	return ColorOpts{Tolerance: opt.Tolerance, Distance: opt.Distance}
+}
+
+// waitFor capture the region and call the fn until it return true,
+// return the TimeoutError with the last frame when timeout
+func waitFor(op string, region interface{}, opt WaitOpts,
+	fn func(src *image.RGBA, o Point) bool) error {
+	deadline := time.Now().Add(time.Duration(opt.Timeout) * time.Millisecond)
+	for {
+		src, o, err := regionRGBA(region)
+		if err != nil {
+			return err
+		}
+		if fn(src, o) {
+			return nil
+		}
+
+		left := time.Until(deadline)
+		if left <= 0 {
+			return &TimeoutError{Op: op, Frame: src, Origin: o}
+		}
+		time.Sleep(min(left, time.Duration(opt.Interval)*time.Millisecond))
+	}
+}
+
+// WaitForImage wait the template image appear in the region,
+// return the match or the *TimeoutError,
+// the template and region are the same as FindBitmap()
+//
+// robotgo.WaitForImage(tpl, region interface{}, opts WaitOpts)
+//
+// Examples:
+//
+//	tpl, _ := robotgo.Read("ok.png")
+//	m, err := robotgo.WaitForImage(tpl, nil, robotgo.WaitOpts{Timeout: 5000})
+//	if e, ok := err.(*robotgo.TimeoutError); ok {
+//		robotgo.SavePng(e.Frame, "timeout.png")
+//	}
+func WaitForImage(tpl, region interface{}, opts ...WaitOpts) (Match, error) {
+	opt := waitOpt(opts)
+	img, useAlpha, err := templateRGBA(tpl)
+	if err != nil {
+		return Match{}, err
+	}
+	t := rgbaTemplate(img, useAlpha)
+	t.dist = distFunc(opt.Distance)
+
+	var m Match
+	err = waitFor("WaitForImage", region, opt, func(src *image.RGBA, o Point) bool {
+		var ok bool
+		m, ok = findBest(src, t, opt.Tolerance)
+		m.X += o.X
+		m.Y += o.Y
+		return ok
+	})
+	if err != nil {
+		return Match{}, err
+	}
+	return m, nil
+}
+
+// WaitForImageGone wait the template image disappear from the region
+func WaitForImageGone(tpl, region interface{}, opts ...WaitOpts) error {
+	opt := waitOpt(opts)
+	img, useAlpha, err := templateRGBA(tpl)
+	if err != nil {
+		return err
+	}
+	t := rgbaTemplate(img, useAlpha)
+	t.dist = distFunc(opt.Distance)
+
+	return waitFor("WaitForImageGone", region, opt, func(src *image.RGBA, o Point) bool {
+		_, ok := findBest(src, t, opt.Tolerance)
+		return !ok
+	})
+}
+
+// WaitForColor wait the color appear in the region,
+// return the first pixel point or the *TimeoutError
+//
+// Examples:
+//
+//	pt, err := robotgo.WaitForColor(0xffffff, robotgo.Rect{
+//		Point: robotgo.Point{X: 100, Y: 100}, Size: robotgo.Size{W: 1, H: 1}})
+func WaitForColor(color CHex, region interface{}, opts ...WaitOpts) (Point, error) {
+	opt := waitOpt(opts)
+
+	var pt Point
+	err := waitFor("WaitForColor", region, opt, func(src *image.RGBA, o Point) bool {
+		var ok bool
+		pt, ok = newColorScan(color, src, o, opt.colorOpts()).first()
+		return ok
+	})
+	return pt, err
+}
+
+// WaitForPixelChange wait the pixel color change, return the new color
+func WaitForPixelChange(x, y int, opts ...WaitOpts) (CHex, error) {
+	opt := waitOpt(opts)
+	region := Rect{Point{X: x, Y: y}, Size{W: 1, H: 1}}
+
+	var (
+		orig, hex CHex
+		first     = true
+	)
+	err := waitFor("WaitForPixelChange", region, opt, func(src *image.RGBA, o Point) bool {
+		hex = CHex(uint32(src.Pix[0])<<16 | uint32(src.Pix[1])<<8 | uint32(src.Pix[2]))
+		if first {
+			orig, first = hex, false
+			return false
+		}
+
+		_, ok := newColorScan(orig, src, o, opt.colorOpts()).first()
+		return !ok
+	})
+	return hex, err
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2016-2025 AtomAI, All rights reserved.
+//
+// See the COPYRIGHT file at the top-level directory of this distribution and at
+// https://github.com/go-vgo/robotgo/blob/master/LICENSE
+//
+// Licensed under the Apache License, Version 2.0 <LICENSE-APACHE or
+// http://www.apache.org/licenses/LICENSE-2.0>
+//
+// This file may not be copied, modified, or distributed
+// except according to those terms.
+
+package robotgo
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/vcaesar/tt"
+)
+
+func TestWaitFor(t *testing.T) {
+	src := testScreen(200, 100)
+	testButton(src, 30, 40)
+	tpl := src.SubImage(image.Rect(30, 40, 70, 60))
+	opt := WaitOpts{Timeout: 50, Interval: 10}
+
+	m, err := WaitForImage(tpl, src, opt)
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 30, Y: 40}, m.Point)
+
+	pt, err := WaitForColor(0x2060c0, src, opt)
+	tt.Nil(t, err)
+	tt.Equal(t, Point{X: 30, Y: 40}, pt)
+
+	start := time.Now()
+	err = WaitForImageGone(tpl, src, opt)
+	tt.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	e, ok := err.(*TimeoutError)
+	tt.True(t, ok)
+	tt.True(t, e.Timeout())
+	tt.Equal(t, "WaitForImageGone: wait timeout", e.Error())
+	tt.Equal(t, src, e.Frame)
+
+	_, err = WaitForColor(0xff0000, src, opt)
+	_, ok = err.(*TimeoutError)
+	tt.True(t, ok)
+
+	_, err = WaitForImage("tpl", src, opt)
+	_, ok = err.(*TimeoutError)
+	tt.False(t, ok)
+}